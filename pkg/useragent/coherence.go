@@ -0,0 +1,223 @@
+package ua
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Rules tying together versions that real browsers report together.
+
+// safariOnIOS reports whether Safari version runs on iOS version (17_4_1
+// format). iOS Safari is versioned with the OS, so major and minor match.
+func safariOnIOS(version, ios string) bool {
+	return majorMinor(version, ".") == majorMinor(ios, "_")
+}
+
+// majorMinor returns the first two parts of v split by sep, padding a
+// missing minor with "0"
+func majorMinor(v, sep string) string {
+	parts := strings.SplitN(v, sep, 3)
+	if len(parts) == 1 {
+		return parts[0] + ".0"
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// macOSFrozen is the macOS version sent by browsers that stopped
+// reporting the real release, see freezesMacOS
+const macOSFrozen = "10_15_7"
+
+// macOrdinal numbers macOS releases consecutively from OS X 10.10 (0):
+// 10.15 is 5, 11 (Big Sur) is 6, 15 (Sequoia) is 10 and 26 (Tahoe) is 11.
+// mac is in 10_15_7 format.
+func macOrdinal(mac string) (int, bool) {
+	parts := strings.SplitN(mac, "_", 3)
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, false
+	}
+	switch {
+	case major == 10 && len(parts) > 1:
+		minor, err := strconv.Atoi(parts[1])
+		return minor - 10, err == nil && minor >= 10
+	case major >= 26:
+		return major - 15, true
+	case major >= 11:
+		return major - 5, true
+	}
+	return 0, false
+}
+
+// safariMacOrdinal returns the ordinal (see macOrdinal) of the macOS
+// release Safari major shipped with
+func safariMacOrdinal(major int) int {
+	if major >= 26 {
+		return major - 15
+	}
+	return major - 8
+}
+
+// edgeChromium returns the Chrome token sent by Edge version.
+// Edge majors track the Chromium release they are built on. Legacy
+// User-Agents of a full Edge build send its full version: a release of
+// that major from the Chrome data, or else the branch build with the
+// patch of the Edge release.
+func (g *Generator) edgeChromium(version string) string {
+	major := majorVersion(version)
+	if !g.legacy || !fullBuild(version) {
+		return major + ".0.0.0"
+	}
+	for _, v := range g.t.chrome.values {
+		if majorVersion(v) == major && fullBuild(v) {
+			return v
+		}
+	}
+	if build, ok := chromiumBuilds[major]; ok {
+		return major + ".0." + build + version[strings.LastIndexByte(version, '.'):]
+	}
+	return major + ".0.0.0"
+}
+
+// fullBuild reports whether a Chromium version carries its build and
+// patch numbers, unlike the reduced 142.0.0.0
+func fullBuild(version string) bool {
+	return strings.Count(version, ".") == 3 && !strings.HasSuffix(version, ".0.0")
+}
+
+// fullVersion returns the full version a release of version sends in
+// Sec-CH-UA-Full-Version-List: version itself if it has a build, or else
+// the branch build of its major in builds with releasePatch. Majors
+// missing from builds keep the reduced version.
+func fullVersion(version string, builds map[string]string) string {
+	if fullBuild(version) {
+		return version
+	}
+	major := majorVersion(version)
+	if build, ok := builds[major]; ok {
+		return major + ".0." + build + "." + releasePatch(major)
+	}
+	return version
+}
+
+// releasePatch returns the patch number of the full versions made up for
+// major. The usage data has none, so every identity of a major agrees on
+// one in the range stable releases reach.
+func releasePatch(major string) string {
+	n, _ := strconv.Atoi(major)
+	return strconv.Itoa(40 + int(mix64(uint64(n))%120))
+}
+
+// Build numbers of the Chromium release branches, by major
+var chromiumBuilds = map[string]string{
+	"90": "4430", "91": "4472", "92": "4515", "93": "4577", "94": "4606",
+	"95": "4638", "96": "4664", "97": "4692", "98": "4758", "99": "4844",
+	"100": "4896", "101": "4951", "102": "5005", "103": "5060", "104": "5112",
+	"105": "5195", "106": "5249", "107": "5304", "108": "5359", "109": "5414",
+	"110": "5481", "111": "5563", "112": "5615", "113": "5672", "114": "5735",
+	"115": "5790", "116": "5845", "117": "5938", "118": "5993", "119": "6045",
+	"120": "6099", "121": "6167", "122": "6261", "123": "6312", "124": "6367",
+	"125": "6422", "126": "6478", "127": "6533", "128": "6613", "129": "6668",
+	"130": "6723", "131": "6778", "132": "6834", "133": "6943", "134": "6998",
+	"135": "7049", "136": "7103", "137": "7151", "138": "7204", "139": "7258",
+	"140": "7339", "141": "7390", "142": "7444", "143": "7499",
+}
+
+// Build numbers of the Edge release branches, by major
+var edgeBuilds = map[string]string{
+	"109": "1518", "110": "1587", "111": "1661", "112": "1722", "113": "1774",
+	"114": "1823", "115": "1901", "116": "1938", "117": "2045", "118": "2088",
+	"119": "2151", "120": "2210", "121": "2277", "122": "2365", "123": "2420",
+	"124": "2478", "125": "2535", "126": "2592", "127": "2651", "128": "2739",
+	"129": "2792", "130": "2849", "131": "2903", "132": "2957", "133": "3065",
+	"134": "3124", "135": "3179", "136": "3240", "137": "3296", "138": "3351",
+	"139": "3405", "140": "3485", "141": "3537", "142": "3595", "143": "3650",
+}
+
+// Chromium releases Samsung Internet majors are built on
+var samsungChromiumVersions = map[string]string{
+	"23": "115.0.0.0",
+	"24": "117.0.0.0",
+	"25": "121.0.0.0",
+	"26": "122.0.0.0",
+	"27": "125.0.0.0",
+	"28": "130.0.0.0",
+}
+
+// samsungChromium returns the Chrome token sent by Samsung Internet version,
+// falling back to a random Chrome version for unknown releases
+func (g *Generator) samsungChromium(version string) string {
+	if v, ok := samsungChromiumVersions[majorVersion(version)]; ok {
+		return v
+	}
+	return g.t.chrome.pick(g.rng)
+}
+
+// androidBuildVersions maps Android build ID prefixes to the Android
+// release they belong to. Two-letter prefixes start with Android 15.
+var androidBuildVersions = []struct {
+	prefix  string
+	version string
+}{
+	{"AP", "15"},
+	{"BP", "16"},
+	{"L", "5"},
+	{"M", "6"},
+	{"N", "7"},
+	{"O", "8"},
+	{"P", "9"},
+	{"Q", "10"},
+	{"R", "11"},
+	{"S", "12"},
+	{"T", "13"},
+	{"U", "14"},
+}
+
+// androidBuildVersion returns the Android release of a build ID such as
+// "UP1A.231005.007" (14)
+func androidBuildVersion(build string) (string, bool) {
+	for _, b := range androidBuildVersions {
+		if strings.HasPrefix(build, b.prefix) {
+			return b.version, true
+		}
+	}
+	return "", false
+}
+
+// device picks an Android device from p and the Android version it runs:
+// one the model is seen on, with a build of that version, or else the
+// version its build ID belongs to
+func (g *Generator) device(p *platforms) (androidDevice, string) {
+	d := p.devices.pick(g.rng)
+	if versions, ok := p.deviceAndroid[d.model]; ok {
+		v := versions.pick(g.rng)
+		if build, ok := p.androidBuild(d.model, v); ok {
+			d.build = build
+			return d, v
+		}
+		if _, known := androidBuildVersion(d.build); !known {
+			return d, v
+		}
+	}
+	if v, ok := androidBuildVersion(d.build); ok {
+		return d, v
+	}
+	return d, p.android.pick(g.rng)
+}
+
+// androidBuild returns a build ID of Android version from the devices in
+// p, preferring one of model
+func (p *platforms) androidBuild(model, version string) (string, bool) {
+	found := ""
+	for _, d := range p.devices.values {
+		if v, ok := androidBuildVersion(d.build); !ok || v != version {
+			continue
+		}
+		if d.model == model {
+			return d.build, true
+		}
+		if found == "" {
+			found = d.build
+		}
+	}
+	return found, found != ""
+}
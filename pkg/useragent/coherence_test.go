@@ -0,0 +1,77 @@
+package ua
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSafariTracksOS(t *testing.T) {
+	g := WithSeed(42)
+
+	for i := 0; i < 1000; i++ {
+		for _, id := range []Identity{g.SafariIOSIdentity(), g.SafariIPadIdentity()} {
+			if majorMinor(id.BrowserVersion, ".") != majorMinor(id.OSVersion, ".") {
+				t.Fatalf("Safari %s on iOS %s: %s", id.BrowserVersion, id.OSVersion, id.UserAgent)
+			}
+		}
+
+		id := g.SafariIdentity()
+		mac := strings.ReplaceAll(id.OSVersion, ".", "_")
+		_, major := compatBrowser(id.Browser, id.BrowserVersion)
+		if !macTokenCompatible(BrowserSafari, major, mac) && mac != macFallback(BrowserSafari, major) {
+			t.Fatalf("Safari %s on macOS %s: %s", id.BrowserVersion, id.OSVersion, id.UserAgent)
+		}
+	}
+}
+
+func TestEdgeChromeTokenMatches(t *testing.T) {
+	g := WithSeed(42)
+
+	for i := 0; i < 500; i++ {
+		for _, id := range []Identity{g.EdgeIdentity(), g.EdgeAndroidIdentity()} {
+			chrome, _ := tokenVersion(id.UserAgent, "Chrome/")
+			if majorVersion(chrome) != majorVersion(id.BrowserVersion) {
+				t.Fatalf("Edge %s with Chrome %s: %s", id.BrowserVersion, chrome, id.UserAgent)
+			}
+			hints, _ := id.ClientHints()
+			if !strings.Contains(hints.UA, `"Chromium";v="`+majorVersion(id.BrowserVersion)+`"`) {
+				t.Fatalf("Edge %s with hints %s", id.BrowserVersion, hints.UA)
+			}
+		}
+	}
+}
+
+func TestAndroidDeviceMatchesVersion(t *testing.T) {
+	g := WithSeed(42)
+
+	for i := 0; i < 500; i++ {
+		for _, id := range []Identity{g.ChromeAndroidIdentity(), g.AndroidWebViewIdentity(), g.SamsungBrowserIdentity(), g.EdgeAndroidIdentity()} {
+			want, ok := androidBuildVersion(id.DeviceBuild)
+			if ok && id.OSVersion != want {
+				t.Fatalf("%s build %s on Android %s: %s", id.DeviceModel, id.DeviceBuild, id.OSVersion, id.UserAgent)
+			}
+		}
+	}
+}
+
+func TestAndroidBuildVersion(t *testing.T) {
+	tests := map[string]string{
+		"BP2A.250605.031.A3": "16",
+		"AP3A.240905.015.A2": "15",
+		"UP1A.231005.007":    "14",
+		"TQ3A.230901.001":    "13",
+		"SP1A.210812.016":    "12",
+		"RP1A.200720.012":    "11",
+		"QKQ1.190716.003":    "10",
+		"PPR1.180610.011":    "9",
+		"LRX22G":             "5",
+	}
+	for build, want := range tests {
+		if got, ok := androidBuildVersion(build); !ok || got != want {
+			t.Errorf("androidBuildVersion(%q) = %q, %v; want %q", build, got, ok, want)
+		}
+	}
+	if v, ok := androidBuildVersion("XYZ"); ok {
+		t.Errorf("androidBuildVersion(XYZ) = %q, want none", v)
+	}
+}
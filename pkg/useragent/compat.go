@@ -0,0 +1,130 @@
+package ua
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// OS families each browser ships on
+var browserPlatforms = map[Browser][]OS{
+	BrowserChrome:  {OSWindows, OSMacOS, OSLinux, OSChromeOS, OSAndroid, OSIOS},
+	BrowserFirefox: {OSWindows, OSMacOS, OSLinux, OSAndroid, OSIOS},
+	BrowserEdge:    {OSWindows, OSMacOS, OSLinux, OSAndroid, OSIOS},
+	BrowserSafari:  {OSMacOS, OSIOS},
+	BrowserSamsung: {OSAndroid},
+	BrowserWebView: {OSAndroid},
+}
+
+// compatRule ends support for old OS releases: releases below before
+// run browser majors up to last
+type compatRule struct {
+	browsers []Browser
+	os       OS
+	before   string
+	last     int
+}
+
+var (
+	chromiumBrowsers = []Browser{BrowserChrome, BrowserEdge, BrowserWebView}
+	geckoBrowsers    = []Browser{BrowserFirefox}
+)
+
+// End-of-support releases, newest first within each OS
+var compatRules = []compatRule{
+	{chromiumBrowsers, OSWindows, "10.0", 109}, // Windows 7, 8, 8.1
+	{chromiumBrowsers, OSWindows, "6.1", 49},   // Windows XP, Vista
+	{geckoBrowsers, OSWindows, "10.0", 115},    // Windows 7, 8, 8.1 (ESR 115)
+	{geckoBrowsers, OSWindows, "6.1", 52},      // Windows XP, Vista (ESR 52)
+
+	{chromiumBrowsers, OSMacOS, "12", 138},    // macOS 11
+	{chromiumBrowsers, OSMacOS, "11", 128},    // macOS 10.15
+	{chromiumBrowsers, OSMacOS, "10.15", 116}, // macOS 10.13, 10.14
+	{chromiumBrowsers, OSMacOS, "10.13", 103}, // OS X 10.11, macOS 10.12
+	{chromiumBrowsers, OSMacOS, "10.11", 87},  // OS X 10.10
+	{chromiumBrowsers, OSMacOS, "10.9", 49},   // OS X 10.6 - 10.8
+	{geckoBrowsers, OSMacOS, "10.15", 115},    // macOS 10.12 - 10.14 (ESR 115)
+	{geckoBrowsers, OSMacOS, "10.12", 78},     // OS X 10.9 - 10.11 (ESR 78)
+	{geckoBrowsers, OSMacOS, "10.9", 45},      // OS X 10.6 - 10.8
+
+	{chromiumBrowsers, OSAndroid, "10", 138}, // Android 8, 9
+	{chromiumBrowsers, OSAndroid, "8", 119},  // Android 7
+	{chromiumBrowsers, OSAndroid, "7", 106},  // Android 6
+	{chromiumBrowsers, OSAndroid, "6", 95},   // Android 5
+	{chromiumBrowsers, OSAndroid, "5", 81},   // Android 4.4
+	{geckoBrowsers, OSAndroid, "5", 68},      // Android 4.1 - 4.4
+}
+
+// Compatible reports whether major version of browser b runs on an OS
+// release. osVersion is in Identity's dotted format ("10.0" for Windows
+// NT 10.0, "10.15.7", "17.4.1", "14"); empty matches any release.
+// Browsers and releases without a known rule are compatible, but a
+// browser never runs on an OS family it does not ship on.
+func Compatible(b Browser, major int, os OS, osVersion string) bool {
+	if platforms, ok := browserPlatforms[b]; ok && !slices.Contains(platforms, os) {
+		return false
+	}
+	if osVersion == "" {
+		return true
+	}
+
+	if b == BrowserSafari {
+		switch os {
+		case OSIOS: // iOS Safari is versioned with the OS
+			return majorVersion(osVersion) == strconv.Itoa(major)
+		case OSMacOS: // each Safari supports the macOS it shipped with and two before
+			ord, ok := macOrdinal(strings.ReplaceAll(osVersion, ".", "_"))
+			shipped := safariMacOrdinal(major)
+			return !ok || shipped-2 <= ord && ord <= shipped
+		}
+	}
+
+	for _, r := range compatRules {
+		if r.os == os && major > r.last && slices.Contains(r.browsers, b) &&
+			compareVersions(osVersion, r.before) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// compareVersions compares dotted numeric versions, treating missing
+// parts as zero
+func compareVersions(a, b string) int {
+	for a != "" || b != "" {
+		var x, y string
+		x, a, _ = strings.Cut(a, ".")
+		y, b, _ = strings.Cut(b, ".")
+		xn, _ := strconv.Atoi(x)
+		yn, _ := strconv.Atoi(y)
+		if xn != yn {
+			if xn < yn {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// macTokenCompatible is Compatible for the macOS version sent in the
+// User-Agent (10_15_7 format). Browsers freezing the token at 10_15_7
+// send it from every later macOS release as well.
+func macTokenCompatible(b Browser, major int, token string) bool {
+	if token == macOSFrozen && freezesMacOS(b, major) {
+		return true
+	}
+	return Compatible(b, major, OSMacOS, strings.ReplaceAll(token, "_", "."))
+}
+
+// freezesMacOS reports whether browser major sends 10_15_7 (10.15 for
+// Firefox) regardless of the macOS release
+func freezesMacOS(b Browser, major int) bool {
+	switch b {
+	case BrowserSafari:
+		return major >= 14
+	case BrowserChrome, BrowserEdge, BrowserFirefox:
+		return major >= 87
+	}
+	return false
+}
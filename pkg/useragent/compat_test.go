@@ -0,0 +1,121 @@
+package ua
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompatible(t *testing.T) {
+	tests := []struct {
+		browser Browser
+		major   int
+		os      OS
+		version string
+		want    bool
+	}{
+		{BrowserChrome, 109, OSWindows, "6.1", true},
+		{BrowserChrome, 110, OSWindows, "6.1", false},
+		{BrowserChrome, 110, OSWindows, "6.3", false},
+		{BrowserChrome, 142, OSWindows, "10.0", true},
+		{BrowserEdge, 143, OSWindows, "6.2", false},
+		{BrowserFirefox, 115, OSWindows, "6.1", true},
+		{BrowserFirefox, 116, OSWindows, "6.1", false},
+		{BrowserFirefox, 52, OSWindows, "5.1", true},
+		{BrowserFirefox, 60, OSWindows, "5.1", false},
+
+		{BrowserChrome, 116, OSMacOS, "10.13", true},
+		{BrowserChrome, 117, OSMacOS, "10.14", false},
+		{BrowserChrome, 128, OSMacOS, "10.15.7", true},
+		{BrowserChrome, 129, OSMacOS, "10.15.7", false},
+		{BrowserChrome, 139, OSMacOS, "11.6", false},
+		{BrowserChrome, 142, OSMacOS, "12.0", true},
+		{BrowserChrome, 142, OSMacOS, "10.10.1", false},
+		{BrowserFirefox, 116, OSMacOS, "10.14", false},
+		{BrowserFirefox, 146, OSMacOS, "10.15", true},
+
+		{BrowserSafari, 18, OSMacOS, "15.0", true},
+		{BrowserSafari, 18, OSMacOS, "13.6", true},
+		{BrowserSafari, 18, OSMacOS, "12.6", false},
+		{BrowserSafari, 26, OSMacOS, "14.4", true},
+		{BrowserSafari, 17, OSMacOS, "15.0", false},
+		{BrowserSafari, 13, OSMacOS, "10.15.6", true},
+		{BrowserSafari, 8, OSMacOS, "10.10.1", true},
+		{BrowserSafari, 18, OSIOS, "18.6.2", true},
+		{BrowserSafari, 18, OSIOS, "17.6.1", false},
+		{BrowserSafari, 18, OSWindows, "", false},
+
+		{BrowserChrome, 138, OSAndroid, "9", true},
+		{BrowserChrome, 139, OSAndroid, "9", false},
+		{BrowserChrome, 120, OSAndroid, "7", false},
+		{BrowserWebView, 142, OSAndroid, "5", false},
+		{BrowserChrome, 142, OSAndroid, "10", true},
+		{BrowserSamsung, 25, OSWindows, "10.0", false},
+
+		{BrowserChrome, 142, OSLinux, "", true},
+		{BrowserOpera, 100, OSWindows, "6.1", true}, // no rules
+	}
+	for _, tt := range tests {
+		if got := Compatible(tt.browser, tt.major, tt.os, tt.version); got != tt.want {
+			t.Errorf("Compatible(%s, %d, %s, %q) = %v, want %v", tt.browser, tt.major, tt.os, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestMacTokenCompatible(t *testing.T) {
+	tests := []struct {
+		browser Browser
+		major   int
+		token   string
+		want    bool
+	}{
+		{BrowserChrome, 142, "10_15_7", true}, // frozen, sent from macOS 11+
+		{BrowserChrome, 142, "10_15_6", false},
+		{BrowserChrome, 142, "14_0", true},
+		{BrowserSafari, 18, "10_15_7", true},
+		{BrowserSafari, 13, "10_15_7", true},
+		{BrowserSafari, 13, "11_0", false},
+		{BrowserSafari, 8, "10_15_7", false},
+	}
+	for _, tt := range tests {
+		if got := macTokenCompatible(tt.browser, tt.major, tt.token); got != tt.want {
+			t.Errorf("macTokenCompatible(%s, %d, %q) = %v, want %v", tt.browser, tt.major, tt.token, got, tt.want)
+		}
+	}
+}
+
+func TestGeneratorsProduceCompatiblePairs(t *testing.T) {
+	g := WithSeed(42)
+
+	for i := 0; i < 2000; i++ {
+		id := g.RandomIdentity()
+		if id.Type == TypeBot {
+			continue
+		}
+		b, major := compatBrowser(id.Browser, id.BrowserVersion)
+		ok := Compatible(b, major, id.OS, id.OSVersion)
+		if id.OS == OSMacOS {
+			ok = macTokenCompatible(b, major, strings.ReplaceAll(id.OSVersion, ".", "_"))
+		}
+		if !ok {
+			t.Fatalf("incompatible %s %s on %s %s: %s", id.Browser, id.BrowserVersion, id.OS, id.OSVersion, id.UserAgent)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"10.0", "6.1", 1},
+		{"6.1", "10.0", -1},
+		{"10.15", "10.15.0", 0},
+		{"10.9", "10.15", -1},
+		{"11", "10.15.7", 1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
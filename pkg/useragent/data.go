@@ -4,190 +4,194 @@
 
 package ua
 
+// Where and when this data was generated, see DataInfo
+const (
+	dataSource    = "https://github.com/intoli/user-agents"
+	dataGenerated = "2026-01-18T14:12:30Z"
+)
+
 // Chrome versions extracted from real usage data (sorted by popularity)
-var chromeVersions = []string{
-	"109.0.0.0",
-	"114.0.0.0",
-	"116.0.0.0",
-	"122.0.0.0",
-	"125.0.0.0",
-	"128.0.0.0",
-	"129.0.0.0",
-	"130.0.0.0",
-	"131.0.0.0",
-	"132.0.0.0",
-	"137.0.0.0",
-	"138.0.0.0",
-	"139.0.0.0",
-	"140.0.0.0",
-	"141.0.0.0",
-	"142.0.0.0",
-	"143.0.0.0",
-	"43.0.9500.1535",
-	"56.0.8271.1481",
-	"99.0.4844.51",
-}
+var chromeVersions = newWeighted([]entry[string]{
+	{"142.0.0.0", 0.118342},
+	{"143.0.0.0", 0.094117},
+	{"141.0.0.0", 0.051806},
+	{"140.0.0.0", 0.028551},
+	{"139.0.0.0", 0.017223},
+	{"138.0.0.0", 0.014982},
+	{"131.0.0.0", 0.009934},
+	{"137.0.0.0", 0.008715},
+	{"109.0.0.0", 0.007461},
+	{"132.0.0.0", 0.005218},
+	{"130.0.0.0", 0.004127},
+	{"129.0.0.0", 0.003306},
+	{"128.0.0.0", 0.002874},
+	{"125.0.0.0", 0.002011},
+	{"122.0.0.0", 0.001642},
+	{"116.0.0.0", 0.001105},
+	{"114.0.0.0", 0.000973},
+	{"99.0.4844.51", 0.000412},
+})
 
 // Firefox versions extracted from real usage data
-var firefoxVersions = []string{
-	"115.0",
-	"133.0",
-	"140.0",
-	"143.0",
-	"144.0",
-	"145.0",
-	"146.0",
-	"147.0",
-	"148.0",
-	"78.0",
-}
+var firefoxVersions = newWeighted([]entry[string]{
+	{"146.0", 0.021875},
+	{"145.0", 0.012541},
+	{"147.0", 0.006632},
+	{"144.0", 0.003418},
+	{"140.0", 0.002957},
+	{"115.0", 0.001763},
+	{"143.0", 0.001208},
+	{"148.0", 0.000872},
+	{"133.0", 0.000594},
+	{"78.0", 0.000227},
+})
 
 // Safari versions extracted from real usage data
-var safariVersions = []string{
-	"15.5",
-	"15.6.1",
-	"16.5.1",
-	"16.6",
-	"16.6.1",
-	"17.0",
-	"17.14",
-	"17.6",
-	"18.1",
-	"18.3",
-	"18.4",
-	"18.5",
-	"18.6",
-	"18.7",
-	"18.7.2",
-	"18.7.3",
-	"26.1",
-	"26.2",
-	"60.5",
-	"8.0.2",
-}
+var safariVersions = newWeighted([]entry[string]{
+	{"18.6", 0.013127},
+	{"26.1", 0.009874},
+	{"18.5", 0.005322},
+	{"26.2", 0.004411},
+	{"17.6", 0.003902},
+	{"18.3", 0.002118},
+	{"18.1", 0.001546},
+	{"16.6", 0.001327},
+	{"18.4", 0.001105},
+	{"18.7", 0.000986},
+	{"17.0", 0.000741},
+	{"15.6.1", 0.000698},
+	{"16.5.1", 0.000457},
+	{"18.7.2", 0.000433},
+	{"16.6.1", 0.000372},
+	{"18.7.3", 0.000351},
+	{"15.5", 0.000302},
+})
 
 // Edge versions extracted from real usage data
-var edgeVersions = []string{
-	"123.0.0.0",
-	"125.0.0.0",
-	"138.0.0.0",
-	"139.0.0.0",
-	"140.0.0.0",
-	"141.0.0.0",
-	"142.0.0.0",
-	"143.0.0.0",
-	"144.0.0.0",
-}
+var edgeVersions = newWeighted([]entry[string]{
+	{"143.0.0.0", 0.016214},
+	{"142.0.0.0", 0.011873},
+	{"144.0.0.0", 0.002545},
+	{"141.0.0.0", 0.001937},
+	{"140.0.0.0", 0.000868},
+	{"139.0.0.0", 0.000611},
+	{"138.0.0.0", 0.000492},
+	{"125.0.0.0", 0.000314},
+	{"123.0.0.0", 0.000288},
+})
 
 // Windows NT versions (sorted by popularity)
-var windowsVersions = []string{
-	"10.0",
-	"6.1",
-	"6.2",
-}
+var windowsVersions = newWeighted([]entry[string]{
+	{"10.0", 0.442176},
+	{"6.1", 0.006843},
+	{"6.2", 0.000715},
+})
 
 // macOS versions (underscore format: 14_2_1)
-var macVersions = []string{
-	"10_10_1",
-	"10_12_0",
-	"10_13_0",
-	"10_14_0",
-	"10_15_6",
-	"10_15_7",
-	"11_0",
-	"11_6",
-	"12_0",
-	"12_6",
-	"13_0",
-	"13_6",
-	"14_0",
-	"14_4",
-	"15_0",
-}
+var macVersions = newWeighted([]entry[string]{
+	{"10_15_7", 0.161239},
+	{"10_15_6", 0.000418},
+	{"14_0", 0.000392},
+	{"10_12_0", 0.000377},
+	{"10_14_0", 0.000361},
+	{"10_13_0", 0.000348},
+	{"10_10_1", 0.000344},
+	{"11_0", 0.000344},
+	{"11_6", 0.000344},
+	{"12_0", 0.000344},
+	{"12_6", 0.000344},
+	{"13_0", 0.000344},
+	{"13_6", 0.000344},
+	{"14_4", 0.000344},
+	{"15_0", 0.000344},
+})
 
 // Linux desktop platforms extracted from real usage data
-var linuxDesktops = []string{
-	"X11; Linux aarch64",
-	"X11; Linux x86_64",
-	"X11; Ubuntu; Linux x86_64",
-	"X11; Linux i686",
-	"X11; Fedora; Linux x86_64",
-	"X11; Debian; Linux x86_64",
-	"X11; Arch Linux; Linux x86_64",
-	"X11; CentOS; Linux x86_64",
-}
+var linuxDesktops = newWeighted([]entry[string]{
+	{"X11; Linux x86_64", 0.093622},
+	{"X11; Ubuntu; Linux x86_64", 0.008714},
+	{"X11; Linux aarch64", 0.000325},
+	{"X11; Linux i686", 0.000325},
+	{"X11; Fedora; Linux x86_64", 0.000325},
+	{"X11; Debian; Linux x86_64", 0.000325},
+	{"X11; Arch Linux; Linux x86_64", 0.000325},
+	{"X11; CentOS; Linux x86_64", 0.000325},
+})
 
 // iOS versions (underscore format: 17_4_1)
-var iosVersions = []string{
-	"11_0",
-	"16_7_12",
-	"17_4_1",
-	"17_5_1",
-	"17_6_1",
-	"18_1_1",
-	"18_3_1",
-	"18_3_2",
-	"18_5",
-	"18_5_0",
-	"18_6",
-	"18_6_1",
-	"18_6_2",
-	"18_7",
-	"18_7_1",
-	"18_7_2",
-	"26_1",
-	"26_1_0",
-	"26_2_0",
-	"26_3_0",
-}
+var iosVersions = newWeighted([]entry[string]{
+	{"18_6_2", 0.008813},
+	{"26_1_0", 0.004217},
+	{"18_7", 0.003925},
+	{"26_2_0", 0.002652},
+	{"18_6", 0.002231},
+	{"18_5", 0.001947},
+	{"18_7_2", 0.001315},
+	{"17_6_1", 0.001198},
+	{"26_1", 0.001037},
+	{"18_3_2", 0.000932},
+	{"18_7_1", 0.000841},
+	{"16_7_12", 0.000779},
+	{"18_6_1", 0.000655},
+	{"18_5_0", 0.000521},
+	{"18_1_1", 0.000467},
+	{"17_5_1", 0.000398},
+	{"18_3_1", 0.000381},
+	{"26_3_0", 0.000352},
+	{"17_4_1", 0.000331},
+	{"11_0", 0.000305},
+})
 
 // Android versions
-var androidVersions = []string{
-	"10",
-	"11",
-	"12",
-	"13",
-	"15",
-	"16",
-	"5",
-	"6",
-	"8",
-	"9",
-}
+var androidVersions = newWeighted([]entry[string]{
+	{"10", 0.029844},
+	{"16", 0.003102},
+	{"15", 0.002477},
+	{"13", 0.001418},
+	{"12", 0.000973},
+	{"11", 0.000805},
+	{"6", 0.000462},
+	{"8", 0.000391},
+	{"9", 0.000376},
+	{"5", 0.000342},
+})
 
 // Android devices extracted from real usage data (model, build ID)
-var androidDevices = []struct {
-	model string
-	build string
-}{
-	{"SM-A205W", "RP1A.200720.012"},
-	{"SM-S921W", "AP3A.240905.015.A2"},
-	{"SM-S937W", "BP2A.250605.031.A3"},
-	{"UTBook_15", "AP3A.241105.008"},
-	{"Pixel 6", "TQ3A.230901.001"},
-	{"Pixel 7", "TQ3A.230901.001"},
-	{"Pixel 7 Pro", "TQ3A.230901.001"},
-	{"Pixel 8", "UQ1A.231205.015"},
-	{"Pixel 8 Pro", "UQ1A.231205.015"},
-	{"SM-S901B", "TP1A.220624.014"},
-	{"SM-S908B", "TP1A.220624.014"},
-	{"SM-S911B", "TP1A.220624.014"},
-	{"SM-S918B", "TP1A.220624.014"},
-	{"SM-S921B", "UP1A.231005.007"},
-	{"SM-S928B", "UP1A.231005.007"},
-	{"SM-A536B", "TP1A.220624.014"},
-	{"SM-A546B", "UP1A.231005.007"},
-	{"SM-G998B", "TP1A.220624.014"},
-	{"ONEPLUS A6013", "QKQ1.190716.003"},
-	{"IN2025", "RKQ1.211119.001"},
-	{"CPH2451", "TP1A.220905.001"},
-	{"M2101K6G", "TKQ1.221114.001"},
-	{"2201116SG", "TKQ1.221114.001"},
-	{"23049PCD8G", "UKQ1.231003.002"},
-	{"RMX3363", "TP1A.220905.001"},
-	{"V2111", "TP1A.220624.014"},
-	{"LE2125", "RKQ1.211119.001"},
-}
+var androidDevices = newWeighted([]entry[androidDevice]{
+	{androidDevice{"SM-S921W", "AP3A.240905.015.A2"}, 0.000731},
+	{androidDevice{"SM-S937W", "BP2A.250605.031.A3"}, 0.000518},
+	{androidDevice{"SM-A205W", "RP1A.200720.012"}, 0.000344},
+	{androidDevice{"UTBook_15", "AP3A.241105.008"}, 0.000301},
+	{androidDevice{"Pixel 6", "TQ3A.230901.001"}, 0.000301},
+	{androidDevice{"Pixel 7", "TQ3A.230901.001"}, 0.000301},
+	{androidDevice{"Pixel 7 Pro", "TQ3A.230901.001"}, 0.000301},
+	{androidDevice{"Pixel 8", "UQ1A.231205.015"}, 0.000301},
+	{androidDevice{"Pixel 8 Pro", "UQ1A.231205.015"}, 0.000301},
+	{androidDevice{"SM-S901B", "TP1A.220624.014"}, 0.000301},
+	{androidDevice{"SM-S908B", "TP1A.220624.014"}, 0.000301},
+	{androidDevice{"SM-S911B", "TP1A.220624.014"}, 0.000301},
+	{androidDevice{"SM-S918B", "TP1A.220624.014"}, 0.000301},
+	{androidDevice{"SM-S921B", "UP1A.231005.007"}, 0.000301},
+	{androidDevice{"SM-S928B", "UP1A.231005.007"}, 0.000301},
+	{androidDevice{"SM-A536B", "TP1A.220624.014"}, 0.000301},
+	{androidDevice{"SM-A546B", "UP1A.231005.007"}, 0.000301},
+	{androidDevice{"SM-G998B", "TP1A.220624.014"}, 0.000301},
+	{androidDevice{"ONEPLUS A6013", "QKQ1.190716.003"}, 0.000301},
+	{androidDevice{"IN2025", "RKQ1.211119.001"}, 0.000301},
+	{androidDevice{"CPH2451", "TP1A.220905.001"}, 0.000301},
+	{androidDevice{"M2101K6G", "TKQ1.221114.001"}, 0.000301},
+	{androidDevice{"2201116SG", "TKQ1.221114.001"}, 0.000301},
+	{androidDevice{"23049PCD8G", "UKQ1.231003.002"}, 0.000301},
+	{androidDevice{"RMX3363", "TP1A.220905.001"}, 0.000301},
+	{androidDevice{"V2111", "TP1A.220624.014"}, 0.000301},
+	{androidDevice{"LE2125", "RKQ1.211119.001"}, 0.000301},
+})
+
+// OS releases seen with each browser version, see BrowserOSWeight
+var browserOSVersions = []BrowserOSWeight{}
+
+// Android versions seen with each device model, see DeviceAndroidWeight
+var deviceAndroidVersions = []DeviceAndroidWeight{}
 
 // WebKit version (used in Safari)
 const webkitVersion = "605.1.15"
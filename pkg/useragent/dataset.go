@@ -0,0 +1,306 @@
+package ua
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Dataset is the usage data a Generator samples from: browser versions,
+// OS releases and Android devices weighted by popularity. The default is
+// compiled in from data.go; a Dataset loaded at runtime lets fresher
+// versions roll out without rebuilding:
+//
+//	d, err := ua.LoadDataset("user-agents.json")
+//	if err != nil { ... }
+//	g := ua.New(ua.WithDataset(d))
+//
+// Tables left empty keep the default data. Version formats match the
+// User-Agent strings: "142.0.0.0", "10.0" (Windows NT), "14_2_1" (macOS,
+// iOS), "14" (Android) and platform tokens such as "X11; Linux x86_64".
+type Dataset struct {
+	// ID identifies the dataset in Identity.DatasetID. Left empty, it is
+	// derived from the contents.
+	ID string `json:"id,omitempty"`
+
+	// Source and Generated tell where the usage data came from and when
+	// it was extracted, see DataInfo
+	Source    string    `json:"source,omitempty"`
+	Generated time.Time `json:"generated,omitzero"`
+
+	Chrome  []VersionWeight `json:"chrome,omitempty"`
+	Firefox []VersionWeight `json:"firefox,omitempty"`
+	Safari  []VersionWeight `json:"safari,omitempty"`
+	Edge    []VersionWeight `json:"edge,omitempty"`
+	Samsung []VersionWeight `json:"samsung,omitempty"`
+
+	Windows        []VersionWeight `json:"windows,omitempty"`
+	MacOS          []VersionWeight `json:"macos,omitempty"`
+	Linux          []VersionWeight `json:"linux,omitempty"`
+	IOS            []VersionWeight `json:"ios,omitempty"`
+	Android        []VersionWeight `json:"android,omitempty"`
+	AndroidDevices []DeviceWeight  `json:"androidDevices,omitempty"`
+
+	// Joint tables, see BrowserOSWeight. Entries for browser versions or
+	// OS releases missing above are never picked.
+	BrowserOS     []BrowserOSWeight     `json:"browserOS,omitempty"`
+	DeviceAndroid []DeviceAndroidWeight `json:"deviceAndroid,omitempty"`
+}
+
+// VersionWeight is a version and its relative popularity
+type VersionWeight struct {
+	Version string  `json:"version"`
+	Weight  float64 `json:"weight"`
+}
+
+// DeviceWeight is an Android device model, the build ID it ships with
+// and its relative popularity
+type DeviceWeight struct {
+	Model  string  `json:"model"`
+	Build  string  `json:"build"`
+	Weight float64 `json:"weight"`
+}
+
+// DefaultDataset returns the data compiled into the package.
+// Marshal it with encoding/json to start a dataset file.
+func DefaultDataset() *Dataset {
+	t := defaultTables
+	d := &Dataset{
+		Source:    t.source,
+		Generated: t.generated,
+		Chrome:    versionWeights(t.chrome),
+		Firefox:   versionWeights(t.firefox),
+		Safari:    versionWeights(t.safari),
+		Edge:      versionWeights(t.edge),
+		Samsung:   versionWeights(t.samsung),
+		Windows:   versionWeights(t.windows),
+		MacOS:     versionWeights(t.mac),
+		Linux:     versionWeights(t.linux),
+		IOS:       versionWeights(t.ios),
+		Android:   versionWeights(t.android),
+	}
+	for i, dev := range t.androidDevices.values {
+		d.AndroidDevices = append(d.AndroidDevices, DeviceWeight{dev.model, dev.build, t.androidDevices.weights[i]})
+	}
+	d.BrowserOS = append([]BrowserOSWeight(nil), t.browserOS...)
+	d.DeviceAndroid = append([]DeviceAndroidWeight(nil), t.deviceAndroid...)
+	return d
+}
+
+func versionWeights(w weighted[string]) []VersionWeight {
+	vw := make([]VersionWeight, len(w.values))
+	for i, v := range w.values {
+		vw[i] = VersionWeight{v, w.weights[i]}
+	}
+	return vw
+}
+
+// ReadDataset decodes a JSON Dataset from r and validates it
+func ReadDataset(r io.Reader) (*Dataset, error) {
+	var d Dataset
+	if err := json.NewDecoder(r).Decode(&d); err != nil {
+		return nil, fmt.Errorf("ua: decoding dataset: %w", err)
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// LoadDataset reads a JSON Dataset from the file at path
+func LoadDataset(path string) (*Dataset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("ua: loading dataset: %w", err)
+	}
+	defer f.Close()
+	return ReadDataset(f)
+}
+
+// Validate reports the first entry of d with an empty or malformed
+// version or a weight that is negative, NaN or infinite, and joint
+// entries naming an unknown browser or OS
+func (d *Dataset) Validate() error {
+	tables := []struct {
+		name    string
+		entries []VersionWeight
+		numeric bool // versions start with a number
+	}{
+		{"chrome", d.Chrome, true},
+		{"firefox", d.Firefox, true},
+		{"safari", d.Safari, true},
+		{"edge", d.Edge, true},
+		{"samsung", d.Samsung, true},
+		{"windows", d.Windows, true},
+		{"macos", d.MacOS, true},
+		{"linux", d.Linux, false},
+		{"ios", d.IOS, true},
+		{"android", d.Android, true},
+	}
+	for _, t := range tables {
+		for _, e := range t.entries {
+			if e.Version == "" || t.numeric && (e.Version[0] < '0' || e.Version[0] > '9') {
+				return fmt.Errorf("ua: dataset %s: malformed version %q", t.name, e.Version)
+			}
+			if !validWeight(e.Weight) {
+				return fmt.Errorf("ua: dataset %s: version %s has weight %g", t.name, e.Version, e.Weight)
+			}
+		}
+	}
+	for _, dev := range d.AndroidDevices {
+		if dev.Model == "" || dev.Build == "" {
+			return fmt.Errorf("ua: dataset androidDevices: device %q without model or build", dev.Model+" "+dev.Build)
+		}
+		if !validWeight(dev.Weight) {
+			return fmt.Errorf("ua: dataset androidDevices: %s has weight %g", dev.Model, dev.Weight)
+		}
+	}
+	return d.validateJoint()
+}
+
+// missingTable returns the name of the first table of d without an entry
+// that can be picked, which WithDataset fills from the default data
+func (d *Dataset) missingTable() string {
+	tables := []struct {
+		name    string
+		entries []VersionWeight
+	}{
+		{"chrome", d.Chrome}, {"firefox", d.Firefox}, {"safari", d.Safari},
+		{"edge", d.Edge}, {"samsung", d.Samsung}, {"windows", d.Windows},
+		{"macos", d.MacOS}, {"linux", d.Linux}, {"ios", d.IOS}, {"android", d.Android},
+	}
+	for _, t := range tables {
+		if len(versionTable(t.entries, weighted[string]{}).values) == 0 {
+			return t.name
+		}
+	}
+	if len(deviceTable(d.AndroidDevices, weighted[androidDevice]{}).values) == 0 {
+		return "androidDevices"
+	}
+	return ""
+}
+
+func validWeight(w float64) bool {
+	return w >= 0 && !math.IsInf(w, 0) && !math.IsNaN(w)
+}
+
+// WithDataset makes the Generator sample from d. Entries without a
+// positive weight are skipped and tables without any keep the default
+// data. d is compiled when WithDataset is called, so reuse the Option
+// to share the work between generators; later changes to d have no
+// effect on it.
+func WithDataset(d *Dataset) Option {
+	t := defaultTables
+	if d != nil {
+		t = d.compile()
+	}
+	return func(g *Generator) {
+		g.t, g.pinned = t, true
+	}
+}
+
+// compile builds the tables a Generator samples from
+func (d *Dataset) compile() *tables {
+	def := defaultTables
+	return (&tables{
+		chrome:         versionTable(d.Chrome, def.chrome),
+		firefox:        versionTable(d.Firefox, def.firefox),
+		safari:         versionTable(d.Safari, def.safari),
+		edge:           versionTable(d.Edge, def.edge),
+		samsung:        versionTable(d.Samsung, def.samsung),
+		windows:        versionTable(d.Windows, def.windows),
+		mac:            versionTable(d.MacOS, def.mac),
+		linux:          versionTable(d.Linux, def.linux),
+		ios:            versionTable(d.IOS, def.ios),
+		android:        versionTable(d.Android, def.android),
+		androidDevices: deviceTable(d.AndroidDevices, def.androidDevices),
+		browserOS:      append([]BrowserOSWeight(nil), d.BrowserOS...),
+		deviceAndroid:  append([]DeviceAndroidWeight(nil), d.DeviceAndroid...),
+		id:             cmp.Or(d.ID, d.hash()),
+		source:         d.Source,
+		generated:      d.Generated,
+	}).link()
+}
+
+// hash derives a dataset ID from the contents of d
+func (d *Dataset) hash() string {
+	c := *d
+	c.ID = ""
+	raw, _ := json.Marshal(&c) // plain data, cannot fail
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:6])
+}
+
+// active holds the tables behind the package-level functions and every
+// Generator not bound to a dataset with WithDataset
+var active atomic.Pointer[tables]
+
+func init() {
+	defaultTables.source = dataSource
+	defaultTables.generated, _ = time.Parse(time.RFC3339, dataGenerated)
+	defaultTables.id = DefaultDataset().hash()
+	active.Store(defaultTables)
+}
+
+// SetDataset atomically replaces the dataset behind the package-level
+// functions and every Generator not bound to one with WithDataset,
+// including existing ones. Each User-Agent is generated entirely from
+// the dataset active when it started. A nil d restores the default data.
+// SetDataset is safe to call while other goroutines generate.
+func SetDataset(d *Dataset) {
+	t := defaultTables
+	if d != nil {
+		t = d.compile()
+	}
+	active.Store(t)
+}
+
+// DatasetID returns the ID of the dataset set with SetDataset, or of the
+// default data
+func DatasetID() string {
+	return active.Load().id
+}
+
+// refresh picks up a dataset swapped in with SetDataset. Generators call
+// it once per User-Agent before touching any table, so a swap never
+// mixes two datasets in one User-Agent.
+func (g *Generator) refresh() {
+	if !g.pinned {
+		g.t = active.Load()
+	}
+}
+
+// versionTable returns the alias table for vw, or def if no entry has
+// a usable weight
+func versionTable(vw []VersionWeight, def weighted[string]) weighted[string] {
+	var entries []entry[string]
+	for _, e := range vw {
+		if e.Version != "" && e.Weight > 0 && validWeight(e.Weight) {
+			entries = append(entries, entry[string]{e.Version, e.Weight})
+		}
+	}
+	if len(entries) == 0 {
+		return def
+	}
+	return newWeighted(entries)
+}
+
+func deviceTable(dw []DeviceWeight, def weighted[androidDevice]) weighted[androidDevice] {
+	var entries []entry[androidDevice]
+	for _, d := range dw {
+		if d.Model != "" && d.Build != "" && d.Weight > 0 && validWeight(d.Weight) {
+			entries = append(entries, entry[androidDevice]{androidDevice{d.Model, d.Build}, d.Weight})
+		}
+	}
+	if len(entries) == 0 {
+		return def
+	}
+	return newWeighted(entries)
+}
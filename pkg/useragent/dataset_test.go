@@ -0,0 +1,172 @@
+package ua
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDefaultDatasetRoundTrip(t *testing.T) {
+	raw, err := json.Marshal(DefaultDataset())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := ReadDataset(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := WithSeed(9), WithSeed(9, WithDataset(d))
+	for i := 0; i < 1000; i++ {
+		if x, y := a.Random(), b.Random(); x != y {
+			t.Fatalf("iteration %d: default %q, round-tripped dataset %q", i, x, y)
+		}
+	}
+}
+
+func TestWithDataset(t *testing.T) {
+	d := &Dataset{
+		Chrome:         []VersionWeight{{"150.0.0.0", 1}, {"149.0.0.0", 0}},
+		Android:        []VersionWeight{{"16", 1}},
+		AndroidDevices: []DeviceWeight{{"Pixel 10", "BP2A.250805.005", 1}},
+	}
+	g := WithSeed(3, WithDataset(d), WithLegacyUA())
+
+	for i := 0; i < 200; i++ {
+		if id := g.ChromeIdentity(); id.BrowserVersion != "150.0.0.0" {
+			t.Fatalf("Chrome %s outside the dataset", id.BrowserVersion)
+		}
+		if id := g.ChromeAndroidIdentity(); id.DeviceModel != "Pixel 10" || id.OSVersion != "16" {
+			t.Fatalf("Android device %s on %s outside the dataset", id.DeviceModel, id.OSVersion)
+		}
+		// Tables missing from the dataset keep the default data
+		if id := g.FirefoxIdentity(); !slices.Contains(defaultTables.firefox.values, id.BrowserVersion) {
+			t.Fatalf("Firefox %s not in the default data", id.BrowserVersion)
+		}
+	}
+
+	if id := WithSeed(3).ChromeIdentity(); id.BrowserVersion == "150.0.0.0" {
+		t.Error("WithDataset changed the default generator")
+	}
+}
+
+func TestLoadDataset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dataset.json")
+	if err := os.WriteFile(path, []byte(`{"safari": [{"version": "27.0", "weight": 1}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := LoadDataset(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Safari) != 1 || d.Safari[0].Version != "27.0" {
+		t.Errorf("Safari = %v", d.Safari)
+	}
+
+	if _, err := LoadDataset(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("loading a missing file succeeded")
+	}
+}
+
+func TestReadDatasetInvalid(t *testing.T) {
+	tests := []string{
+		`{"chrome": [{"version": "142.0.0.0", "weight": -1}]}`,
+		`{"chrome": [{"version": "", "weight": 1}]}`,
+		`{"macos": [{"version": "Sonoma", "weight": 1}]}`,
+		`{"androidDevices": [{"model": "Pixel 8", "weight": 1}]}`,
+		`{"chrome": "142"}`,
+		`[`,
+	}
+	for _, in := range tests {
+		if _, err := ReadDataset(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadDataset(%s) succeeded", in)
+		}
+	}
+}
+
+func TestSetDataset(t *testing.T) {
+	t.Cleanup(func() { SetDataset(nil) })
+
+	def := DatasetID()
+	if def == "" || def != defaultTables.id {
+		t.Fatalf("default dataset ID %q", def)
+	}
+	if id := DefaultDataset().compile().id; id != def {
+		t.Errorf("compiled default dataset ID %q, want %q", id, def)
+	}
+
+	existing := WithSeed(4)
+	pinned := WithSeed(4, WithDataset(nil))
+	SetDataset(&Dataset{ID: "next", Chrome: []VersionWeight{{"150.0.0.0", 1}}})
+	if got := DatasetID(); got != "next" {
+		t.Fatalf("DatasetID() = %q after SetDataset", got)
+	}
+
+	for i := 0; i < 100; i++ {
+		if id := existing.ChromeIdentity(); id.BrowserVersion != "150.0.0.0" || id.DatasetID != "next" {
+			t.Fatalf("existing generator ignored the swap: %s from %q", id.BrowserVersion, id.DatasetID)
+		}
+		if id := ChromeAndroidIdentity(); id.DatasetID != "next" {
+			t.Fatalf("package-level identity from dataset %q", id.DatasetID)
+		}
+		if id := pinned.ChromeIdentity(); id.DatasetID != def {
+			t.Fatalf("WithDataset generator followed the swap to %q", id.DatasetID)
+		}
+	}
+
+	SetDataset(nil)
+	if id := existing.FirefoxIdentity(); id.DatasetID != def {
+		t.Errorf("SetDataset(nil) left dataset %q active", id.DatasetID)
+	}
+}
+
+func TestSetDatasetConcurrent(t *testing.T) {
+	t.Cleanup(func() { SetDataset(nil) })
+
+	datasets := map[string]string{"a": "150.0.0.0", "b": "151.0.0.0"}
+	var swaps sync.WaitGroup
+	stop := make(chan struct{})
+	swaps.Add(1)
+	go func() {
+		defer swaps.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			id := []string{"a", "b"}[i%2]
+			SetDataset(&Dataset{ID: id, Chrome: []VersionWeight{{datasets[id], 1}}})
+		}
+	}()
+
+	var wg sync.WaitGroup
+	errs := make(chan string, 8)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g := New()
+			for i := 0; i < 500; i++ {
+				for _, id := range []Identity{ChromeIdentity(), g.ChromeAndroidIdentity()} {
+					if want, ok := datasets[id.DatasetID]; ok && id.BrowserVersion != want {
+						errs <- "Chrome " + id.BrowserVersion + " from dataset " + id.DatasetID
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	swaps.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,61 @@
+package extract
+
+import (
+	"strconv"
+	"strings"
+
+	ua "github.com/nzrsky/useragent-generator/pkg/useragent"
+)
+
+// browserVersions pairs a browser with its extracted versions
+type browserVersions struct {
+	browser  ua.Browser
+	versions []VersionWeight
+}
+
+// dropIncompatible removes OS releases that no extracted browser version
+// runs on according to ua.Compatible, so generators never pair them
+func dropIncompatible(data *Data) {
+	desktop := []browserVersions{
+		{ua.BrowserChrome, data.ChromeVersions},
+		{ua.BrowserFirefox, data.FirefoxVersions},
+		{ua.BrowserSafari, data.SafariVersions},
+		{ua.BrowserEdge, data.EdgeVersions},
+	}
+	mobile := []browserVersions{
+		{ua.BrowserChrome, data.ChromeVersions},
+		{ua.BrowserFirefox, data.FirefoxVersions},
+		{ua.BrowserEdge, data.EdgeVersions},
+	}
+
+	data.WindowsVersions = keepCompatible(data.WindowsVersions, ua.OSWindows, desktop, &data.Dropped)
+	data.MacVersions = keepCompatible(data.MacVersions, ua.OSMacOS, desktop, &data.Dropped)
+	data.AndroidVersions = keepCompatible(data.AndroidVersions, ua.OSAndroid, mobile, &data.Dropped)
+}
+
+// keepCompatible returns the releases of os at least one of browsers runs on.
+// Versions are converted from the User-Agent form (10_15_7) to dotted form.
+func keepCompatible(releases []VersionWeight, os ua.OS, browsers []browserVersions, dropped *[]Drop) []VersionWeight {
+	kept := releases[:0]
+	for _, r := range releases {
+		// Current browsers send 10_15_7 from every later macOS release
+		if os == ua.OSMacOS && r.Version == "10_15_7" || runsAny(os, strings.ReplaceAll(r.Version, "_", "."), browsers) {
+			kept = append(kept, r)
+			continue
+		}
+		*dropped = append(*dropped, Drop{string(os), r.Version, r.Weight, "no extracted browser version runs on it"})
+	}
+	return kept
+}
+
+func runsAny(os ua.OS, release string, browsers []browserVersions) bool {
+	for _, b := range browsers {
+		for _, v := range b.versions {
+			major, err := strconv.Atoi(strings.SplitN(v.Version, ".", 2)[0])
+			if err == nil && ua.Compatible(b.browser, major, os, release) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package extract
+
+import ua "github.com/nzrsky/useragent-generator/pkg/useragent"
+
+// Dataset converts d into a ua.Dataset, ready to be marshaled to JSON
+// and loaded with ua.LoadDataset. Samsung Internet versions are not
+// extracted, so it gets the ones compiled into the package and the
+// dataset has every table.
+func (d Data) Dataset() *ua.Dataset {
+	ds := &ua.Dataset{
+		Chrome:  uaVersions(d.ChromeVersions),
+		Firefox: uaVersions(d.FirefoxVersions),
+		Safari:  uaVersions(d.SafariVersions),
+		Edge:    uaVersions(d.EdgeVersions),
+		Samsung: ua.DefaultDataset().Samsung,
+		Windows: uaVersions(d.WindowsVersions),
+		MacOS:   uaVersions(d.MacVersions),
+		Linux:   uaVersions(d.LinuxDesktops),
+		IOS:     uaVersions(d.IOSVersions),
+		Android: uaVersions(d.AndroidVersions),
+	}
+	for _, dev := range d.AndroidDevices {
+		ds.AndroidDevices = append(ds.AndroidDevices, ua.DeviceWeight{Model: dev.Model, Build: dev.Build, Weight: dev.Weight})
+	}
+	for _, p := range d.BrowserOS {
+		ds.BrowserOS = append(ds.BrowserOS, ua.BrowserOSWeight{Browser: p.Browser, Version: p.Version, OS: p.OS, OSVersion: p.OSVersion, Weight: p.Weight})
+	}
+	for _, p := range d.DeviceAndroid {
+		ds.DeviceAndroid = append(ds.DeviceAndroid, ua.DeviceAndroidWeight{Model: p.Model, Android: p.Android, Weight: p.Weight})
+	}
+	return ds
+}
+
+func uaVersions(vw []VersionWeight) []ua.VersionWeight {
+	out := make([]ua.VersionWeight, len(vw))
+	for i, v := range vw {
+		out[i] = ua.VersionWeight{Version: v.Version, Weight: v.Weight}
+	}
+	return out
+}
@@ -0,0 +1,320 @@
+package extract
+
+import (
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	ua "github.com/nzrsky/useragent-generator/pkg/useragent"
+)
+
+// MinShift is the change in share a kept entry needs to be reported by Diff
+const MinShift = 0.005
+
+// diffTables lists the tables Diff compares, with the data.go variable
+// each one is generated into
+var diffTables = []struct {
+	name  string // Dataset JSON name
+	title string
+	goVar string // empty for tables data.go does not hold
+	get   func(*ua.Dataset) *[]ua.VersionWeight
+}{
+	{"chrome", "Chrome", "chromeVersions", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.Chrome }},
+	{"firefox", "Firefox", "firefoxVersions", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.Firefox }},
+	{"safari", "Safari", "safariVersions", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.Safari }},
+	{"edge", "Edge", "edgeVersions", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.Edge }},
+	{"samsung", "Samsung Internet", "", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.Samsung }},
+	{"windows", "Windows", "windowsVersions", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.Windows }},
+	{"macos", "macOS", "macVersions", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.MacOS }},
+	{"linux", "Linux", "linuxDesktops", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.Linux }},
+	{"ios", "iOS", "iosVersions", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.IOS }},
+	{"android", "Android", "androidVersions", func(d *ua.Dataset) *[]ua.VersionWeight { return &d.Android }},
+}
+
+// data.go variables of the device and joint tables
+const (
+	devicesVar       = "androidDevices"
+	browserOSVar     = "browserOSVersions"
+	deviceAndroidVar = "deviceAndroidVersions"
+)
+
+// TableDiff is the change of one table between two datasets
+type TableDiff struct {
+	Table   string   // Dataset JSON name, e.g. "chrome"
+	Title   string   // e.g. "Chrome", "Android devices"
+	Added   []Change // heaviest first
+	Removed []Change // heaviest first
+	Shifted []Change // kept entries whose share moved by MinShift or more, largest move first
+}
+
+// Change is a table entry with its share of the table's total weight
+// before and after, 0 where it is absent
+type Change struct {
+	Value    string // version, or "model Build/build" for devices
+	Old, New float64
+}
+
+// Diff compares the tables of old and next, leaving out tables without
+// added, removed or shifted entries. A table emptied or filled by the
+// update is reported as all removed or all added, except tables data.go
+// does not hold, which are skipped when empty on either side.
+func Diff(old, next *ua.Dataset) []TableDiff {
+	var diffs []TableDiff
+	add := func(table, title string, d TableDiff) {
+		if d.changed() {
+			d.Table, d.Title = table, title
+			diffs = append(diffs, d)
+		}
+	}
+	for _, t := range diffTables {
+		if t.goVar == "" && (len(*t.get(old)) == 0 || len(*t.get(next)) == 0) {
+			continue
+		}
+		add(t.name, t.title, diffShares(shares(*t.get(old)), shares(*t.get(next))))
+	}
+	add("androidDevices", "Android devices", diffShares(deviceShares(old.AndroidDevices), deviceShares(next.AndroidDevices)))
+	add("browserOS", "Browsers by OS", diffShares(browserOSShares(old.BrowserOS), browserOSShares(next.BrowserOS)))
+	add("deviceAndroid", "Android versions by device", diffShares(deviceAndroidShares(old.DeviceAndroid), deviceAndroidShares(next.DeviceAndroid)))
+	return diffs
+}
+
+func (d TableDiff) changed() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Shifted) > 0
+}
+
+// shares returns each version's share of the table's total weight
+func shares(vw []ua.VersionWeight) map[string]float64 {
+	var total float64
+	for _, e := range vw {
+		total += e.Weight
+	}
+	m := make(map[string]float64, len(vw))
+	for _, e := range vw {
+		if total > 0 {
+			m[e.Version] += e.Weight / total
+		}
+	}
+	return m
+}
+
+func deviceShares(dw []ua.DeviceWeight) map[string]float64 {
+	vw := make([]ua.VersionWeight, len(dw))
+	for i, d := range dw {
+		vw[i] = ua.VersionWeight{Version: d.Model + " Build/" + d.Build, Weight: d.Weight}
+	}
+	return shares(vw)
+}
+
+// browserOSShares keys joint entries as "chrome 142.0.0.0 windows 10.0"
+func browserOSShares(bw []ua.BrowserOSWeight) map[string]float64 {
+	vw := make([]ua.VersionWeight, len(bw))
+	for i, b := range bw {
+		vw[i] = ua.VersionWeight{Version: b.Browser + " " + b.Version + " " + b.OS + " " + b.OSVersion, Weight: b.Weight}
+	}
+	return shares(vw)
+}
+
+// deviceAndroidShares keys joint entries as "Pixel 8 Android 15"
+func deviceAndroidShares(dw []ua.DeviceAndroidWeight) map[string]float64 {
+	vw := make([]ua.VersionWeight, len(dw))
+	for i, d := range dw {
+		vw[i] = ua.VersionWeight{Version: d.Model + " Android " + d.Android, Weight: d.Weight}
+	}
+	return shares(vw)
+}
+
+func diffShares(old, next map[string]float64) TableDiff {
+	var d TableDiff
+	for v, share := range next {
+		prev, ok := old[v]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, Change{v, 0, share})
+		case share-prev >= MinShift || prev-share >= MinShift:
+			d.Shifted = append(d.Shifted, Change{v, prev, share})
+		}
+	}
+	for v, share := range old {
+		if _, ok := next[v]; !ok {
+			d.Removed = append(d.Removed, Change{v, share, 0})
+		}
+	}
+
+	heaviest := func(a, b Change) int {
+		return cmp.Or(cmp.Compare(max(b.Old, b.New), max(a.Old, a.New)), strings.Compare(a.Value, b.Value))
+	}
+	slices.SortFunc(d.Added, heaviest)
+	slices.SortFunc(d.Removed, heaviest)
+	slices.SortFunc(d.Shifted, func(a, b Change) int {
+		return cmp.Or(cmp.Compare(abs(b.New-b.Old), abs(a.New-a.Old)), strings.Compare(a.Value, b.Value))
+	})
+	return d
+}
+
+func abs(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// WriteChangelog writes diffs as a Markdown changelog, one section per table
+func WriteChangelog(w io.Writer, diffs []TableDiff) error {
+	var b strings.Builder
+	if len(diffs) == 0 {
+		b.WriteString("No changes to the usage data.\n")
+	}
+	for i, d := range diffs {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "#### %s\n\n", d.Title)
+		for _, c := range d.Added {
+			fmt.Fprintf(&b, "- Added `%s` (%s)\n", c.Value, percent(c.New))
+		}
+		for _, c := range d.Removed {
+			fmt.Fprintf(&b, "- Removed `%s` (was %s)\n", c.Value, percent(c.Old))
+		}
+		for _, c := range d.Shifted {
+			fmt.Fprintf(&b, "- `%s` %s → %s\n", c.Value, percent(c.Old), percent(c.New))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func percent(share float64) string {
+	return strconv.FormatFloat(share*100, 'f', 1, 64) + "%"
+}
+
+// ParseDataFile reads the tables of a data.go written by
+// scripts/generate_data.go, including revisions from before weights were
+// recorded, whose entries all get weight 1
+func ParseDataFile(src []byte) (*ua.Dataset, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "data.go", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parsing data.go: %w", err)
+	}
+
+	vars := make(map[string]ast.Expr)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) == 1 && len(vs.Values) == 1 {
+				vars[vs.Names[0].Name] = vs.Values[0]
+			}
+		}
+	}
+
+	d := &ua.Dataset{}
+	for _, t := range diffTables {
+		for _, e := range entries(vars[t.goVar]) {
+			if len(e.values) == 1 {
+				*t.get(d) = append(*t.get(d), ua.VersionWeight{Version: e.values[0], Weight: e.weight})
+			}
+		}
+	}
+	for _, e := range entries(vars[devicesVar]) {
+		if len(e.values) == 2 {
+			d.AndroidDevices = append(d.AndroidDevices, ua.DeviceWeight{Model: e.values[0], Build: e.values[1], Weight: e.weight})
+		}
+	}
+	for _, e := range entries(vars[browserOSVar]) {
+		if len(e.values) == 4 {
+			d.BrowserOS = append(d.BrowserOS, ua.BrowserOSWeight{Browser: e.values[0], Version: e.values[1], OS: e.values[2], OSVersion: e.values[3], Weight: e.weight})
+		}
+	}
+	for _, e := range entries(vars[deviceAndroidVar]) {
+		if len(e.values) == 2 {
+			d.DeviceAndroid = append(d.DeviceAndroid, ua.DeviceAndroidWeight{Model: e.values[0], Android: e.values[1], Weight: e.weight})
+		}
+	}
+	if len(d.Chrome) == 0 {
+		return nil, fmt.Errorf("parsing data.go: no %s table", diffTables[0].goVar)
+	}
+
+	if lit, ok := vars["dataSource"].(*ast.BasicLit); ok {
+		d.Source, _ = strconv.Unquote(lit.Value)
+	}
+	if lit, ok := vars["dataGenerated"].(*ast.BasicLit); ok {
+		s, _ := strconv.Unquote(lit.Value)
+		d.Generated, _ = time.Parse(time.RFC3339, s)
+	}
+	return d, nil
+}
+
+// literalEntry is an element of a data.go table: its strings and weight
+type literalEntry struct {
+	values []string
+	weight float64
+}
+
+// entries reads the elements of a table literal, either
+// newWeighted([]entry[T]{{value, weight}, ...}), a bare []T{value, ...}
+// or a joint table []T{{value, ..., weight}, ...}
+func entries(expr ast.Expr) []literalEntry {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		expr = call.Args[0]
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	var out []literalEntry
+	for _, elt := range lit.Elts {
+		e := literalEntry{weight: 1}
+		if fields, ok := elt.(*ast.CompositeLit); ok && len(fields.Elts) >= 2 {
+			last := len(fields.Elts) - 1
+			if w, ok := number(fields.Elts[last]); ok {
+				e.weight = w
+				for _, f := range fields.Elts[:last] {
+					e.values = append(e.values, stringValues(f)...)
+				}
+				out = append(out, e)
+				continue
+			}
+		}
+		e.values = stringValues(elt)
+		out = append(out, e)
+	}
+	return out
+}
+
+// stringValues returns the string literal expr, or the string fields of
+// the composite literal expr
+func stringValues(expr ast.Expr) []string {
+	if lit, ok := expr.(*ast.CompositeLit); ok {
+		var values []string
+		for _, elt := range lit.Elts {
+			values = append(values, stringValues(elt)...)
+		}
+		return values
+	}
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		s, err := strconv.Unquote(lit.Value)
+		if err == nil {
+			return []string{s}
+		}
+	}
+	return nil
+}
+
+func number(expr ast.Expr) (float64, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || (lit.Kind != token.FLOAT && lit.Kind != token.INT) {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(lit.Value, 64)
+	return f, err == nil
+}
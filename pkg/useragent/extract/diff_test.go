@@ -0,0 +1,155 @@
+package extract
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	ua "github.com/nzrsky/useragent-generator/pkg/useragent"
+)
+
+func TestParseDataFile(t *testing.T) {
+	src, err := os.ReadFile("../data.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseDataFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := ua.DefaultDataset()
+	want.Samsung = nil // not generated into data.go
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("data.go parsed to %+v, want the default dataset %+v", got, want)
+	}
+}
+
+func TestParseDataFileUnweighted(t *testing.T) {
+	src := `package ua
+
+var chromeVersions = []string{
+	"131.0.0.0",
+	"130.0.0.0",
+}
+
+var androidDevices = []struct {
+	model string
+	build string
+}{
+	{"SM-S911B", "UP1A.231005.007"},
+}
+`
+	d, err := ParseDataFile([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []ua.VersionWeight{vw("131.0.0.0", 1), vw("130.0.0.0", 1)}; !reflect.DeepEqual(d.Chrome, want) {
+		t.Errorf("Chrome = %v, want %v", d.Chrome, want)
+	}
+	if want := []ua.DeviceWeight{dw("SM-S911B", "UP1A.231005.007", 1)}; !reflect.DeepEqual(d.AndroidDevices, want) {
+		t.Errorf("AndroidDevices = %v, want %v", d.AndroidDevices, want)
+	}
+
+	if _, err := ParseDataFile([]byte("package ua\n")); err == nil {
+		t.Error("parsed a file without tables")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := &ua.Dataset{
+		Chrome:         []ua.VersionWeight{vw("142.0.0.0", 0.5), vw("141.0.0.0", 0.3), vw("109.0.0.0", 0.199), vw("99.0.0.0", 0.001)},
+		Firefox:        []ua.VersionWeight{vw("146.0", 1)},
+		Samsung:        []ua.VersionWeight{vw("25.0", 1)},
+		AndroidDevices: []ua.DeviceWeight{dw("Pixel 8", "AP2A.240805.005", 1)},
+	}
+	next := &ua.Dataset{
+		Chrome:         []ua.VersionWeight{vw("143.0.0.0", 0.4), vw("142.0.0.0", 0.3), vw("141.0.0.0", 0.298), vw("99.0.0.0", 0.002)},
+		Firefox:        []ua.VersionWeight{vw("146.0", 2)}, // same share
+		AndroidDevices: []ua.DeviceWeight{dw("Pixel 8", "AP2A.240805.005", 1), dw("Pixel 9", "BP1A.250505.005", 1)},
+	}
+
+	diffs := Diff(old, next)
+	if len(diffs) != 2 || diffs[0].Table != "chrome" || diffs[1].Table != "androidDevices" {
+		t.Fatalf("Diff = %+v, want chrome and androidDevices", diffs)
+	}
+
+	chrome := diffs[0]
+	if len(chrome.Added) != 1 || chrome.Added[0] != (Change{"143.0.0.0", 0, 0.4}) {
+		t.Errorf("added %v", chrome.Added)
+	}
+	if len(chrome.Removed) != 1 || chrome.Removed[0].Value != "109.0.0.0" {
+		t.Errorf("removed %v", chrome.Removed)
+	}
+	// 141 and 99 moved by less than MinShift
+	if len(chrome.Shifted) != 1 || chrome.Shifted[0] != (Change{"142.0.0.0", 0.5, 0.3}) {
+		t.Errorf("shifted %v", chrome.Shifted)
+	}
+
+	var b strings.Builder
+	if err := WriteChangelog(&b, diffs); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"#### Chrome\n",
+		"- Added `143.0.0.0` (40.0%)\n",
+		"- Removed `109.0.0.0` (was 19.9%)\n",
+		"- `142.0.0.0` 50.0% → 30.0%\n",
+		"#### Android devices\n",
+		"- Added `Pixel 9 Build/BP1A.250505.005` (50.0%)\n",
+		"- `Pixel 8 Build/AP2A.240805.005` 100.0% → 50.0%\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("changelog without %q:\n%s", want, b.String())
+		}
+	}
+
+	b.Reset()
+	WriteChangelog(&b, Diff(old, old))
+	if b.String() != "No changes to the usage data.\n" {
+		t.Errorf("changelog for equal datasets: %q", b.String())
+	}
+}
+
+func TestDiffEmptiedAndJointTables(t *testing.T) {
+	old := &ua.Dataset{
+		Chrome:        []ua.VersionWeight{vw("142.0.0.0", 1)},
+		Firefox:       []ua.VersionWeight{vw("146.0", 3), vw("115.0", 1)},
+		BrowserOS:     []ua.BrowserOSWeight{{Browser: "chrome", Version: "142.0.0.0", OS: "windows", OSVersion: "10.0", Weight: 1}},
+		DeviceAndroid: []ua.DeviceAndroidWeight{{Model: "Pixel 8", Android: "15", Weight: 1}},
+	}
+	next := &ua.Dataset{
+		Chrome:  []ua.VersionWeight{vw("142.0.0.0", 1)},
+		Samsung: []ua.VersionWeight{vw("25.0", 1)}, // not held by data.go
+		BrowserOS: []ua.BrowserOSWeight{
+			{Browser: "chrome", Version: "142.0.0.0", OS: "windows", OSVersion: "10.0", Weight: 1},
+			{Browser: "chrome", Version: "142.0.0.0", OS: "macos", OSVersion: "10_15_7", Weight: 1},
+		},
+	}
+
+	got := map[string]TableDiff{}
+	for _, d := range Diff(old, next) {
+		got[d.Table] = d
+	}
+	if len(got) != 3 {
+		t.Errorf("Diff reports %v, want firefox, browserOS and deviceAndroid", got)
+	}
+	if d := got["firefox"]; len(d.Removed) != 2 || d.Removed[0] != (Change{"146.0", 0.75, 0}) || len(d.Added) != 0 {
+		t.Errorf("emptied firefox table: %+v", d)
+	}
+	if d := got["browserOS"]; len(d.Added) != 1 || d.Added[0].Value != "chrome 142.0.0.0 macos 10_15_7" {
+		t.Errorf("browserOS: %+v", d)
+	}
+	if d := got["deviceAndroid"]; len(d.Removed) != 1 || d.Removed[0].Value != "Pixel 8 Android 15" {
+		t.Errorf("deviceAndroid: %+v", d)
+	}
+}
+
+func vw(version string, weight float64) ua.VersionWeight {
+	return ua.VersionWeight{Version: version, Weight: weight}
+}
+
+func dw(model, build string, weight float64) ua.DeviceWeight {
+	return ua.DeviceWeight{Model: model, Build: build, Weight: weight}
+}
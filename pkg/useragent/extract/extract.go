@@ -0,0 +1,405 @@
+// Package extract turns a real-usage User-Agent dataset, such as the one
+// published by Intoli (https://github.com/intoli/user-agents), into the
+// weighted version tables compiled into package ua.
+//
+// It is used by scripts/generate_data.go:
+//
+//	agents, err := extract.Decode(f) // .json or .json.gz
+//	data := extract.Extract(agents)
+//
+// Diff and WriteChangelog compare two datasets for scripts/diff_data.go.
+package extract
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Number of entries kept per table, heaviest first
+const (
+	TopBrowserVersions = 20
+	TopMacVersions     = 30
+	TopAndroidVersions = 10
+	TopWindowsVersions = 10
+	TopLinuxDesktops   = 20
+	TopAndroidDevices  = 50
+)
+
+// Agent is a single entry of the dataset
+type Agent struct {
+	UserAgent string  `json:"userAgent"`
+	Weight    float64 `json:"weight"`
+}
+
+// VersionWeight holds version string and its cumulative weight
+type VersionWeight struct {
+	Version string
+	Weight  float64
+}
+
+// AndroidDevice is a device model with the build ID it was seen with
+type AndroidDevice struct {
+	Model string
+	Build string
+}
+
+// DeviceWeight holds an Android device and its cumulative weight
+type DeviceWeight struct {
+	AndroidDevice
+	Weight float64
+}
+
+// Data holds all extracted version data, each list sorted by
+// weight descending
+type Data struct {
+	ChromeVersions  []VersionWeight
+	FirefoxVersions []VersionWeight
+	SafariVersions  []VersionWeight
+	EdgeVersions    []VersionWeight
+	IOSVersions     []VersionWeight
+	MacVersions     []VersionWeight
+	AndroidVersions []VersionWeight
+	AndroidDevices  []DeviceWeight
+	WindowsVersions []VersionWeight
+	LinuxDesktops   []VersionWeight
+
+	// Joint tables: the values above seen in the same User-Agent
+	BrowserOS     []BrowserOSWeight
+	DeviceAndroid []DeviceAndroidWeight
+
+	Dropped []Drop // entries rejected by validation, in pipeline order
+}
+
+// Drop records an extracted entry the pipeline rejected
+type Drop struct {
+	Family  string // browser or OS the entry belongs to
+	Version string
+	Weight  float64
+	Reason  string
+}
+
+// Decode reads a dataset in JSON form, gzip-compressed or not
+func Decode(r io.Reader) ([]Agent, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("gzip reader failed: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = br
+	}
+
+	var agents []Agent
+	if err := json.NewDecoder(r).Decode(&agents); err != nil {
+		return nil, fmt.Errorf("JSON decode failed: %w", err)
+	}
+	return agents, nil
+}
+
+var (
+	// Chrome/131.0.6778.85
+	chromeVersionRe = regexp.MustCompile(`Chrome/(\d+\.\d+\.\d+\.\d+)`)
+	// Firefox/133.0
+	firefoxVersionRe = regexp.MustCompile(`Firefox/(\d+\.\d+)`)
+	// Version/17.4 Safari
+	safariVersionRe = regexp.MustCompile(`Version/(\d+\.\d+(?:\.\d+)?) Safari`)
+	// Edg/131.0.2903.51
+	edgeVersionRe = regexp.MustCompile(`Edg/(\d+\.\d+\.\d+\.\d+)`)
+	// iPhone OS 17_4_1 or CPU OS 17_4
+	iosVersionRe = regexp.MustCompile(`(?:iPhone|CPU) OS (\d+_\d+(?:_\d+)?)`)
+	// Mac OS X 14_2_1 or Mac OS X 10_15_7
+	macVersionRe = regexp.MustCompile(`Mac OS X (\d+_\d+(?:_\d+)?)`)
+	// Android 14
+	androidVersionRe = regexp.MustCompile(`Android (\d+)`)
+	// Windows NT 10.0
+	windowsVersionRe = regexp.MustCompile(`Windows NT (\d+\.\d+)`)
+	// Linux; Android 14; SM-S911B Build/UP1A.231005.007
+	androidDeviceRe = regexp.MustCompile(`Android \d+; ([^)]+) Build/([A-Z0-9.]+)`)
+	// X11; Linux x86_64 or X11; Ubuntu; Linux x86_64
+	linuxDesktopRe = regexp.MustCompile(`\((X11; [^)]+)\)`)
+)
+
+// Extract accumulates the weights of every version, OS release, device
+// and Linux platform in agents, validates them and keeps the top entries,
+// along with how often kept browser versions and OS releases, and kept
+// devices and Android versions, appear together
+func Extract(agents []Agent) Data {
+	// Maps to accumulate weights for each version
+	chromeWeights := make(map[string]float64)
+	firefoxWeights := make(map[string]float64)
+	safariWeights := make(map[string]float64)
+	edgeWeights := make(map[string]float64)
+	iosWeights := make(map[string]float64)
+	macWeights := make(map[string]float64)
+	androidWeights := make(map[string]float64)
+	windowsWeights := make(map[string]float64)
+	linuxWeights := make(map[string]float64)
+	deviceWeights := make(map[string]float64) // "model|build" -> weight
+
+	// Values seen in the same User-Agent
+	browserOSWeights := make(map[browserOSKey]float64)
+	deviceAndroidWeights := make(map[deviceAndroidKey]float64)
+
+	for _, ua := range agents {
+		s := ua.UserAgent
+		w := ua.Weight
+		var pair browserOSKey
+
+		// Chrome (but not Edge, Opera, Samsung)
+		if strings.Contains(s, "Chrome/") && !strings.Contains(s, "Edg/") &&
+			!strings.Contains(s, "OPR/") && !strings.Contains(s, "SamsungBrowser") {
+			if m := chromeVersionRe.FindStringSubmatch(s); m != nil {
+				chromeWeights[m[1]] += w
+				pair.browser, pair.version = "chrome", m[1]
+			}
+		}
+
+		// Firefox
+		if m := firefoxVersionRe.FindStringSubmatch(s); m != nil {
+			firefoxWeights[m[1]] += w
+			pair.browser, pair.version = "firefox", m[1]
+		}
+
+		// Safari (not Chrome-based)
+		if strings.Contains(s, "Safari/") && !strings.Contains(s, "Chrome/") {
+			if m := safariVersionRe.FindStringSubmatch(s); m != nil {
+				safariWeights[m[1]] += w
+				pair.browser, pair.version = "safari", m[1]
+			}
+		}
+
+		// Edge
+		if m := edgeVersionRe.FindStringSubmatch(s); m != nil {
+			edgeWeights[m[1]] += w
+			pair.browser, pair.version = "edge", m[1]
+		}
+
+		// iOS
+		if m := iosVersionRe.FindStringSubmatch(s); m != nil {
+			iosWeights[m[1]] += w
+			pair.os, pair.osVersion = "ios", m[1]
+		}
+
+		// macOS
+		if m := macVersionRe.FindStringSubmatch(s); m != nil {
+			macWeights[m[1]] += w
+			pair.os, pair.osVersion = "macos", m[1]
+		}
+
+		// Android version
+		var android string
+		if m := androidVersionRe.FindStringSubmatch(s); m != nil {
+			androidWeights[m[1]] += w
+			android = m[1]
+			pair.os, pair.osVersion = "android", m[1]
+		}
+
+		// Windows
+		if m := windowsVersionRe.FindStringSubmatch(s); m != nil {
+			windowsWeights[m[1]] += w
+			pair.os, pair.osVersion = "windows", m[1]
+		}
+
+		if pair.browser != "" && pair.os != "" {
+			browserOSWeights[pair] += w
+		}
+
+		// Android device
+		if m := androidDeviceRe.FindStringSubmatch(s); m != nil {
+			model := strings.TrimSpace(m[1])
+			build := m[2]
+			// Skip generic "K" model
+			if model != "K" && len(model) > 2 {
+				key := model + "|" + build
+				deviceWeights[key] += w
+				if android != "" {
+					deviceAndroidWeights[deviceAndroidKey{model, android}] += w
+				}
+			}
+		}
+
+		// Linux desktop (X11; Linux x86_64, etc.)
+		if strings.Contains(s, "X11;") && strings.Contains(s, "Linux") && !strings.Contains(s, "Android") {
+			if m := linuxDesktopRe.FindStringSubmatch(s); m != nil {
+				platform := m[1]
+				// Remove Firefox rv: suffix
+				if idx := strings.Index(platform, "; rv:"); idx != -1 {
+					platform = platform[:idx]
+				}
+				linuxWeights[platform] += w
+			}
+		}
+	}
+
+	var dropped []Drop
+	dropped = append(dropped, validate(chromeRule, chromeWeights)...)
+	dropped = append(dropped, validate(firefoxRule, firefoxWeights)...)
+	dropped = append(dropped, validate(safariRule, safariWeights)...)
+	dropped = append(dropped, validate(edgeRule, edgeWeights)...)
+
+	data := Data{
+		Dropped:         dropped,
+		ChromeVersions:  topVersions(chromeWeights, TopBrowserVersions),
+		FirefoxVersions: topVersions(firefoxWeights, TopBrowserVersions),
+		SafariVersions:  topVersions(safariWeights, TopBrowserVersions),
+		EdgeVersions:    topVersions(edgeWeights, TopBrowserVersions),
+		IOSVersions:     topVersions(iosWeights, TopBrowserVersions),
+		MacVersions:     topVersions(macWeights, TopMacVersions),
+		AndroidVersions: topVersions(androidWeights, TopAndroidVersions),
+		WindowsVersions: topVersions(windowsWeights, TopWindowsVersions),
+		LinuxDesktops:   topVersions(linuxWeights, TopLinuxDesktops),
+		AndroidDevices:  topDevices(deviceWeights, TopAndroidDevices),
+	}
+
+	// Add fallback data if extracted data is sparse
+	data.LinuxDesktops = mergeUnique(data.LinuxDesktops, fallbackLinuxDesktops)
+	data.MacVersions = mergeUnique(data.MacVersions, fallbackMacVersions)
+	data.AndroidDevices = mergeDevices(data.AndroidDevices, fallbackAndroidDevices)
+
+	dropIncompatible(&data)
+	keepJoint(&data, browserOSWeights, deviceAndroidWeights)
+	return data
+}
+
+// Fallback data for sparse categories
+var fallbackLinuxDesktops = []string{
+	"X11; Linux x86_64",
+	"X11; Linux i686",
+	"X11; Linux aarch64",
+	"X11; Ubuntu; Linux x86_64",
+	"X11; Fedora; Linux x86_64",
+	"X11; Debian; Linux x86_64",
+	"X11; Arch Linux; Linux x86_64",
+	"X11; CentOS; Linux x86_64",
+}
+
+var fallbackMacVersions = []string{
+	"10_15_7", "11_0", "11_6", "12_0", "12_6", "13_0", "13_6", "14_0", "14_4", "15_0",
+}
+
+var fallbackAndroidDevices = []AndroidDevice{
+	{"Pixel 6", "TQ3A.230901.001"},
+	{"Pixel 7", "TQ3A.230901.001"},
+	{"Pixel 7 Pro", "TQ3A.230901.001"},
+	{"Pixel 8", "UQ1A.231205.015"},
+	{"Pixel 8 Pro", "UQ1A.231205.015"},
+	{"SM-S901B", "TP1A.220624.014"}, // Samsung S22
+	{"SM-S908B", "TP1A.220624.014"}, // Samsung S22 Ultra
+	{"SM-S911B", "TP1A.220624.014"}, // Samsung S23
+	{"SM-S918B", "TP1A.220624.014"}, // Samsung S23 Ultra
+	{"SM-S921B", "UP1A.231005.007"}, // Samsung S24
+	{"SM-S928B", "UP1A.231005.007"}, // Samsung S24 Ultra
+	{"SM-A536B", "TP1A.220624.014"}, // Samsung A53
+	{"SM-A546B", "UP1A.231005.007"}, // Samsung A54
+	{"SM-G998B", "TP1A.220624.014"}, // Samsung S21 Ultra
+	{"ONEPLUS A6013", "QKQ1.190716.003"},
+	{"IN2025", "RKQ1.211119.001"},     // OnePlus Nord
+	{"CPH2451", "TP1A.220905.001"},    // OPPO Find X5
+	{"M2101K6G", "TKQ1.221114.001"},   // Xiaomi 11T Pro
+	{"2201116SG", "TKQ1.221114.001"},  // Xiaomi 12
+	{"23049PCD8G", "UKQ1.231003.002"}, // Xiaomi 14
+	{"RMX3363", "TP1A.220905.001"},    // Realme GT 2 Pro
+	{"V2111", "TP1A.220624.014"},      // Vivo X70 Pro
+	{"LE2125", "RKQ1.211119.001"},     // OnePlus 9 Pro
+}
+
+// fallbackWeight is the weight given to fallback entries: the smallest
+// observed weight in the category, so they never outrank real data
+func fallbackWeight(weights []float64) float64 {
+	min := 0.0
+	for _, w := range weights {
+		if min == 0 || w < min {
+			min = w
+		}
+	}
+	if min == 0 {
+		return 1
+	}
+	return min
+}
+
+func mergeUnique(a []VersionWeight, b []string) []VersionWeight {
+	seen := make(map[string]bool)
+	weights := make([]float64, len(a))
+	for i, v := range a {
+		seen[v.Version] = true
+		weights[i] = v.Weight
+	}
+	w := fallbackWeight(weights)
+	result := append([]VersionWeight{}, a...)
+	for _, v := range b {
+		if !seen[v] {
+			result = append(result, VersionWeight{v, w})
+			seen[v] = true
+		}
+	}
+	return result
+}
+
+func mergeDevices(a []DeviceWeight, b []AndroidDevice) []DeviceWeight {
+	seen := make(map[string]bool)
+	weights := make([]float64, len(a))
+	for i, d := range a {
+		seen[d.Model] = true
+		weights[i] = d.Weight
+	}
+	w := fallbackWeight(weights)
+	result := append([]DeviceWeight{}, a...)
+	for _, d := range b {
+		if !seen[d.Model] {
+			result = append(result, DeviceWeight{d, w})
+			seen[d.Model] = true
+		}
+	}
+	return result
+}
+
+// sortByWeight sorts by weight descending, breaking ties by version
+// string for stable output
+func sortByWeight(vw []VersionWeight) {
+	sort.Slice(vw, func(i, j int) bool {
+		if vw[i].Weight != vw[j].Weight {
+			return vw[i].Weight > vw[j].Weight
+		}
+		return vw[i].Version < vw[j].Version
+	})
+}
+
+func topVersions(weights map[string]float64, n int) []VersionWeight {
+	var vw []VersionWeight
+	for v, w := range weights {
+		vw = append(vw, VersionWeight{v, w})
+	}
+
+	sortByWeight(vw)
+
+	// Take top N
+	if len(vw) > n {
+		vw = vw[:n]
+	}
+
+	return vw
+}
+
+func topDevices(weights map[string]float64, n int) []DeviceWeight {
+	vw := topVersions(weights, n)
+
+	result := make([]DeviceWeight, 0, len(vw))
+	for _, v := range vw {
+		parts := strings.SplitN(v.Version, "|", 2)
+		if len(parts) == 2 {
+			result = append(result, DeviceWeight{AndroidDevice{Model: parts[0], Build: parts[1]}, v.Weight})
+		}
+	}
+	return result
+}
@@ -0,0 +1,231 @@
+package extract
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"slices"
+	"testing"
+
+	ua "github.com/nzrsky/useragent-generator/pkg/useragent"
+)
+
+const fixture = "testdata/user-agents.json"
+
+func loadFixture(t *testing.T) []Agent {
+	t.Helper()
+	f, err := os.Open(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	agents, err := Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return agents
+}
+
+func TestDecodeGzip(t *testing.T) {
+	raw, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(raw)
+	zw.Close()
+
+	agents, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := loadFixture(t); !slices.Equal(agents, want) {
+		t.Errorf("gzip decoded %d agents, plain JSON %d", len(agents), len(want))
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, in := range []string{"", "{", `{"userAgent": "x"}`, "\x1f\x8bgarbage"} {
+		if _, err := Decode(bytes.NewBufferString(in)); err == nil {
+			t.Errorf("Decode(%q) succeeded", in)
+		}
+	}
+}
+
+func versions(vw []VersionWeight) []string {
+	out := make([]string, len(vw))
+	for i, v := range vw {
+		out[i] = v.Version
+	}
+	return out
+}
+
+func TestExtract(t *testing.T) {
+	data := Extract(loadFixture(t))
+
+	tests := []struct {
+		name string
+		got  []VersionWeight
+		want []string
+	}{
+		{"Chrome", data.ChromeVersions, []string{"142.0.0.0", "143.0.0.0", "141.0.0.0", "109.0.0.0", "131.0.6778.85"}},
+		{"Firefox", data.FirefoxVersions, []string{"146.0", "145.0", "115.0"}},
+		{"Safari", data.SafariVersions, []string{"18.6", "26.1", "14.1.2"}},
+		{"Edge", data.EdgeVersions, []string{"143.0.0.0", "142.0.3595.94"}},
+		{"iOS", data.IOSVersions, []string{"18_6_2", "17_7"}},
+		{"Windows", data.WindowsVersions, []string{"10.0", "6.1"}},
+		{"Android", data.AndroidVersions, []string{"10", "14", "13"}},
+		{"Linux", data.LinuxDesktops[:2], []string{"X11; Linux x86_64", "X11; Ubuntu; Linux x86_64"}},
+		{"macOS", data.MacVersions[:2], []string{"10_15_7", "10_14_6"}},
+	}
+	for _, tt := range tests {
+		if got := versions(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if w := data.ChromeVersions[0].Weight; w < 0.2322 || w > 0.2324 {
+		t.Errorf("Chrome 142 weight %g, want the sum of its entries 0.2323", w)
+	}
+
+	var devices []string
+	for _, d := range data.AndroidDevices[:3] {
+		devices = append(devices, d.Model+" "+d.Build)
+	}
+	if want := []string{"Pixel 7 TQ3A.230901.001", "SM-S918B UP1A.231005.007", "GT-I9300 IMM76D"}; !slices.Equal(devices, want) {
+		t.Errorf("devices = %q, want %q", devices, want)
+	}
+	if len(data.AndroidDevices) <= 3 || len(data.LinuxDesktops) <= 2 || len(data.MacVersions) <= 2 {
+		t.Error("sparse tables not padded with fallback data")
+	}
+}
+
+func TestExtractJoint(t *testing.T) {
+	data := Extract(loadFixture(t))
+
+	if len(data.BrowserOS) == 0 {
+		t.Fatal("no browser/OS pairs")
+	}
+	if top := data.BrowserOS[0]; top.Browser != "chrome" || top.Version != "142.0.0.0" || top.OS != "windows" || top.OSVersion != "10.0" {
+		t.Errorf("heaviest pair %+v, want Chrome 142 on Windows 10.0", top)
+	}
+	chrome, oses := versionSet(data.ChromeVersions), versionSet(data.WindowsVersions)
+	for _, p := range data.BrowserOS {
+		if p.Browser == "chrome" && !chrome[p.Version] || p.OS == "windows" && !oses[p.OSVersion] {
+			t.Errorf("pair %+v outside the extracted tables", p)
+		}
+	}
+
+	want := []DeviceAndroidWeight{{"Pixel 7", "13", 0.002}, {"SM-S918B", "14", 0.0008}}
+	if !slices.Equal(data.DeviceAndroid, want) {
+		t.Errorf("DeviceAndroid = %v, want %v", data.DeviceAndroid, want)
+	}
+}
+
+func TestExtractDrops(t *testing.T) {
+	data := Extract(loadFixture(t))
+
+	want := map[string]string{
+		"Chrome 131.0.9999.12":  "build 9999 is not a Chrome 131 release",
+		"Chrome 43.0.9500.1535": "major 43 outside 70-200",
+		"Chrome 140.0.0.0":      "weight below 0.0001",
+		"Chrome 99.0.4844.51":   "major 99 is more than 36 behind 143",
+		"Firefox 146.1":         "minor 1 above 0",
+		"Safari 17.14":          "minor 14 above 7",
+		"Safari 60.5":           "major 60 outside 12-30",
+		"Safari 8.0.2":          "major 8 outside 12-30",
+		"Android 4":             "no extracted browser version runs on it",
+	}
+	got := map[string]string{}
+	for _, d := range data.Dropped {
+		got[d.Family+" "+d.Version] = d.Reason
+	}
+	for entry, reason := range want {
+		if got[entry] != reason {
+			t.Errorf("%s dropped for %q, want %q", entry, got[entry], reason)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("dropped %v, want %d entries", got, len(want))
+	}
+}
+
+func TestValidateMaxAge(t *testing.T) {
+	weights := map[string]float64{"146.0": 1, "115.0": 1, "73.0": 1}
+	dropped := validate(firefoxRule, weights)
+	if len(dropped) != 1 || dropped[0].Version != "73.0" {
+		t.Fatalf("dropped %+v, want only 73.0", dropped)
+	}
+	if _, ok := weights["73.0"]; ok {
+		t.Error("dropped version left in weights")
+	}
+}
+
+func TestValidateRareFutureMajor(t *testing.T) {
+	weights := map[string]float64{"143.0.0.0": 0.1, "142.0.0.0": 0.1, "144.0.0.0": 0.002, "109.0.0.0": 0.01, "199.0.0.0": 0.0002}
+	dropped := validate(chromeRule, weights)
+	if len(dropped) != 1 || dropped[0].Version != "199.0.0.0" || dropped[0].Reason != "major 199 is more than 2 ahead of 143" {
+		t.Fatalf("dropped %+v, want only 199.0.0.0", dropped)
+	}
+	if len(weights) != 4 {
+		t.Errorf("kept %v, want the real versions", weights)
+	}
+}
+
+func TestValidateMaxAgeOldRelease(t *testing.T) {
+	// Real releases with valid builds, but too old to still be in use
+	tests := []struct {
+		rule    versionRule
+		weights map[string]float64
+		old     string
+	}{
+		{chromeRule, map[string]float64{"143.0.0.0": 1, "109.0.5414.120": 1, "100.0.4896.127": 1}, "100.0.4896.127"},
+		{edgeRule, map[string]float64{"143.0.0.0": 1, "109.0.1518.140": 1, "100.0.1185.36": 1}, "100.0.1185.36"},
+		{firefoxRule, map[string]float64{"146.0": 1, "115.0": 1, "102.0": 1}, "102.0"},
+	}
+	for _, tt := range tests {
+		dropped := validate(tt.rule, tt.weights)
+		if len(dropped) != 1 || dropped[0].Version != tt.old {
+			t.Errorf("%s: dropped %+v, want only %s", tt.rule.family, dropped, tt.old)
+		}
+	}
+}
+
+func TestKnownBuild(t *testing.T) {
+	tests := []struct {
+		major, build int
+		want         bool
+	}{
+		{131, 6778, true},
+		{131, 6779, false},
+		{99, 4844, true},
+		{80, 3987, true},  // before the table, older build
+		{80, 7000, false}, // before the table, newer build
+		{150, 7900, true}, // after the table, newer build
+		{150, 4000, false},
+	}
+	for _, tt := range tests {
+		if got := knownBuild(chromeBranchBuilds, tt.major, tt.build); got != tt.want {
+			t.Errorf("knownBuild(%d, %d) = %v, want %v", tt.major, tt.build, got, tt.want)
+		}
+	}
+}
+
+func TestDataDataset(t *testing.T) {
+	data := Extract(loadFixture(t))
+	ds := data.Dataset()
+	if err := ds.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ds.Chrome) != len(data.ChromeVersions) || ds.Chrome[0].Version != data.ChromeVersions[0].Version {
+		t.Errorf("Chrome = %v, want %v", ds.Chrome, data.ChromeVersions)
+	}
+	if len(ds.AndroidDevices) != len(data.AndroidDevices) || ds.AndroidDevices[0].Model != data.AndroidDevices[0].Model {
+		t.Errorf("AndroidDevices = %v, want %v", ds.AndroidDevices, data.AndroidDevices)
+	}
+	if want := ua.DefaultDataset().Samsung; !slices.Equal(ds.Samsung, want) {
+		t.Errorf("Samsung = %v, want the default data %v", ds.Samsung, want)
+	}
+}
@@ -0,0 +1,83 @@
+package extract
+
+import (
+	"cmp"
+	"slices"
+)
+
+// BrowserOSWeight holds a browser version seen on an OS release and the
+// cumulative weight of the pair
+type BrowserOSWeight struct {
+	Browser   string // "chrome", "firefox", "safari" or "edge"
+	Version   string
+	OS        string // "windows", "macos", "ios" or "android"
+	OSVersion string
+	Weight    float64
+}
+
+// DeviceAndroidWeight holds an Android device model seen on an Android
+// version and the cumulative weight of the pair
+type DeviceAndroidWeight struct {
+	Model   string
+	Android string
+	Weight  float64
+}
+
+type browserOSKey struct {
+	browser, version, os, osVersion string
+}
+
+type deviceAndroidKey struct {
+	model, android string
+}
+
+// keepJoint sets the joint tables of data to the pairs whose values all
+// made it into its independent tables, heaviest first. Pairs lighter
+// than minWeight are left out as noise.
+func keepJoint(data *Data, browserOS map[browserOSKey]float64, deviceAndroid map[deviceAndroidKey]float64) {
+	browsers := map[string]map[string]bool{
+		"chrome":  versionSet(data.ChromeVersions),
+		"firefox": versionSet(data.FirefoxVersions),
+		"safari":  versionSet(data.SafariVersions),
+		"edge":    versionSet(data.EdgeVersions),
+	}
+	oses := map[string]map[string]bool{
+		"windows": versionSet(data.WindowsVersions),
+		"macos":   versionSet(data.MacVersions),
+		"ios":     versionSet(data.IOSVersions),
+		"android": versionSet(data.AndroidVersions),
+	}
+	data.BrowserOS = nil
+	for k, w := range browserOS {
+		if w >= minWeight && browsers[k.browser][k.version] && oses[k.os][k.osVersion] {
+			data.BrowserOS = append(data.BrowserOS, BrowserOSWeight{k.browser, k.version, k.os, k.osVersion, w})
+		}
+	}
+	slices.SortFunc(data.BrowserOS, func(a, b BrowserOSWeight) int {
+		return cmp.Or(cmp.Compare(b.Weight, a.Weight), cmp.Compare(a.Browser, b.Browser),
+			cmp.Compare(a.Version, b.Version), cmp.Compare(a.OS, b.OS), cmp.Compare(a.OSVersion, b.OSVersion))
+	})
+
+	models := make(map[string]bool, len(data.AndroidDevices))
+	for _, d := range data.AndroidDevices {
+		models[d.Model] = true
+	}
+	android := oses["android"]
+	data.DeviceAndroid = nil
+	for k, w := range deviceAndroid {
+		if w >= minWeight && models[k.model] && android[k.android] {
+			data.DeviceAndroid = append(data.DeviceAndroid, DeviceAndroidWeight{k.model, k.android, w})
+		}
+	}
+	slices.SortFunc(data.DeviceAndroid, func(a, b DeviceAndroidWeight) int {
+		return cmp.Or(cmp.Compare(b.Weight, a.Weight), cmp.Compare(a.Model, b.Model), cmp.Compare(a.Android, b.Android))
+	})
+}
+
+func versionSet(vw []VersionWeight) map[string]bool {
+	set := make(map[string]bool, len(vw))
+	for _, v := range vw {
+		set[v.Version] = true
+	}
+	return set
+}
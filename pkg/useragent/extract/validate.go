@@ -0,0 +1,168 @@
+package extract
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Validation: the usage data contains spoofed and malformed User-Agents
+// ("Chrome/43.0.9500.1535", "Version/60.5 Safari"). Each browser family
+// gets sanity rules, and entries breaking any of them are dropped before
+// the top versions are picked.
+
+// minWeight is the smallest usage weight a browser version needs;
+// rarer versions are mostly spoofed strings
+const minWeight = 0.0001
+
+// newestShare is the share of a family's weight a version needs to count
+// as its newest release, and maxLead how many majors betas and early
+// updates may run ahead of it
+const (
+	newestShare = 0.01
+	maxLead     = 2
+)
+
+// versionRule holds the sanity rules for one browser family
+type versionRule struct {
+	family   string
+	minMajor int
+	maxMajor int
+	maxMinor int         // highest second component a release ever had
+	maxAge   int         // majors behind the newest valid version still kept
+	builds   map[int]int // major -> release branch build, nil if unchecked
+}
+
+var (
+	// Chrome 109, the last release for Windows 7, is the oldest still seen
+	// in real use; anything further behind the newest is spoofed
+	chromeRule = versionRule{
+		family: "Chrome", minMajor: 70, maxMajor: 200, maxMinor: 0, maxAge: 36,
+		builds: chromeBranchBuilds,
+	}
+	// Chromium-based Edge started at 79 and numbers its own builds
+	edgeRule = versionRule{
+		family: "Edge", minMajor: 79, maxMajor: 200, maxMinor: 0, maxAge: 36,
+	}
+	// Firefox sends MAJOR.0 only; the oldest ESR still supported (115 on
+	// Windows 7) trails the newest release by about 30 majors
+	firefoxRule = versionRule{
+		family: "Firefox", minMajor: 60, maxMajor: 200, maxMinor: 0, maxAge: 36,
+	}
+	// Safari ships one major a year, with point releases up to .7
+	safariRule = versionRule{
+		family: "Safari", minMajor: 12, maxMajor: 30, maxMinor: 7, maxAge: 12,
+	}
+)
+
+// chromeBranchBuilds maps Chrome majors to the build number of their
+// release branch. Stable releases always send it as the third component
+// (131.0.6778.85); other builds are canaries, forks or spoofed.
+var chromeBranchBuilds = map[int]int{
+	90: 4430, 91: 4472, 92: 4515, 93: 4577, 94: 4606, 95: 4638, 96: 4664, 97: 4692, 98: 4758, 99: 4844,
+	100: 4896, 101: 4951, 102: 5005, 103: 5060, 104: 5112, 105: 5195, 106: 5249, 107: 5304, 108: 5359, 109: 5414,
+	110: 5481, 111: 5563, 112: 5615, 113: 5672, 114: 5735, 115: 5790, 116: 5845, 117: 5938, 118: 5993, 119: 6045,
+	120: 6099, 121: 6167, 122: 6261, 123: 6312, 124: 6367, 125: 6422, 126: 6478, 127: 6533, 128: 6613, 129: 6668,
+	130: 6723, 131: 6778, 132: 6834, 133: 6943, 134: 6998, 135: 7049, 136: 7103, 137: 7151, 138: 7204, 139: 7258,
+	140: 7339, 141: 7390, 142: 7444, 143: 7499,
+}
+
+// validate deletes the versions in weights that break r and returns
+// what it dropped, heaviest first
+func validate(r versionRule, weights map[string]float64) []Drop {
+	var dropped []Drop
+	drop := func(v, reason string) {
+		dropped = append(dropped, Drop{r.family, v, weights[v], reason})
+		delete(weights, v)
+	}
+
+	total := 0.0
+	for v := range weights {
+		if reason := r.check(v, weights[v]); reason != "" {
+			drop(v, reason)
+			continue
+		}
+		total += weights[v]
+	}
+	// The newest release is the newest one in wide use, so a rare spoofed
+	// or future major cannot age out every real version
+	newest := 0
+	for v, w := range weights {
+		if w >= newestShare*total {
+			newest = max(newest, versionPart(v, 0))
+		}
+	}
+	for v := range weights {
+		switch major := versionPart(v, 0); {
+		case newest-major > r.maxAge:
+			drop(v, fmt.Sprintf("major %d is more than %d behind %d", major, r.maxAge, newest))
+		case major-newest > maxLead:
+			drop(v, fmt.Sprintf("major %d is more than %d ahead of %d", major, maxLead, newest))
+		}
+	}
+
+	sort.Slice(dropped, func(i, j int) bool {
+		if dropped[i].Weight != dropped[j].Weight {
+			return dropped[i].Weight > dropped[j].Weight
+		}
+		return dropped[i].Version < dropped[j].Version
+	})
+	return dropped
+}
+
+// check returns why version v with weight w breaks r, or ""
+func (r versionRule) check(v string, w float64) string {
+	parts := strings.Split(v, ".")
+	nums := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return "malformed version"
+		}
+		nums[i] = n
+	}
+
+	major := nums[0]
+	switch {
+	case major < r.minMajor || major > r.maxMajor:
+		return fmt.Sprintf("major %d outside %d-%d", major, r.minMajor, r.maxMajor)
+	case len(nums) > 1 && nums[1] > r.maxMinor:
+		return fmt.Sprintf("minor %d above %d", nums[1], r.maxMinor)
+	case w < minWeight:
+		return fmt.Sprintf("weight below %g", minWeight)
+	}
+
+	// Reduced versions (142.0.0.0) carry no build to check
+	if r.builds != nil && len(nums) == 4 && nums[2] != 0 && !knownBuild(r.builds, major, nums[2]) {
+		return fmt.Sprintf("build %d is not a %s %d release", nums[2], r.family, major)
+	}
+	return ""
+}
+
+// knownBuild reports whether build is the release branch of major.
+// Majors outside the table only need to fall on the right side of it.
+func knownBuild(builds map[int]int, major, build int) bool {
+	if b, ok := builds[major]; ok {
+		return build == b
+	}
+	lo, hi := math.MaxInt, 0
+	for m := range builds {
+		lo, hi = min(lo, m), max(hi, m)
+	}
+	if major < lo {
+		return build < builds[lo]
+	}
+	return build > builds[hi]
+}
+
+// versionPart returns the i-th dot-separated number of v, 0 if missing
+func versionPart(v string, i int) int {
+	parts := strings.Split(v, ".")
+	if i >= len(parts) {
+		return 0
+	}
+	n, _ := strconv.Atoi(parts[i])
+	return n
+}
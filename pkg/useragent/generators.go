@@ -2,91 +2,181 @@ package ua
 
 import "strings"
 
+// androidDevice is a device model with the build ID it shipped with
+type androidDevice struct {
+	model string
+	build string
+}
+
+// SafariIOS generates a Safari User-Agent for iPhone
 func (g *Generator) SafariIOS() string {
-	iosVer := pick(g.rng, iosVersions)
-	safariVer := pick(g.rng, safariVersions)
+	return g.safariIOS().UserAgent
+}
+
+func (g *Generator) safariIOS() Identity {
+	g.refresh()
+	safariVer := g.t.safari.pick(g.rng)
+	iosVer := g.t.on(BrowserSafari, safariVer).ios.pick(g.rng)
+	id := Identity{
+		Browser:        BrowserSafari,
+		BrowserVersion: safariVer,
+		Engine:         EngineWebKit,
+		OS:             OSIOS,
+		OSVersion:      dotted(iosVer),
+		DeviceModel:    "iPhone",
+		Type:           TypeMobile,
+		DatasetID:      g.t.id,
+	}
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
 
-	b.WriteString("Mozilla/5.0 (iPhone; CPU iPhone OS ")
-	b.WriteString(iosVer)
-	b.WriteString(" like Mac OS X) AppleWebKit/")
+	b.WriteString("Mozilla/5.0 (")
+	iosWebKit(&id, iosVer, &b)
+	b.WriteString(") AppleWebKit/")
 	b.WriteString(webkitVersion)
 	b.WriteString(" (KHTML, like Gecko) Version/")
 	b.WriteString(safariVer)
 	b.WriteString(" Mobile/15E148 Safari/")
 	b.WriteString(webkitVersion)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
+// SafariIPad generates a Safari User-Agent for iPad
 func (g *Generator) SafariIPad() string {
-	iosVer := pick(g.rng, iosVersions)
-	safariVer := pick(g.rng, safariVersions)
+	return g.safariIPad().UserAgent
+}
+
+func (g *Generator) safariIPad() Identity {
+	g.refresh()
+	safariVer := g.t.safari.pick(g.rng)
+	iosVer := g.t.on(BrowserSafari, safariVer).ios.pick(g.rng)
+	id := Identity{
+		Browser:        BrowserSafari,
+		BrowserVersion: safariVer,
+		Engine:         EngineWebKit,
+		OS:             OSIOS,
+		OSVersion:      dotted(iosVer),
+		DeviceModel:    "iPad",
+		Type:           TypeTablet,
+		DatasetID:      g.t.id,
+	}
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
 
-	b.WriteString("Mozilla/5.0 (iPad; CPU OS ")
-	b.WriteString(iosVer)
-	b.WriteString(" like Mac OS X) AppleWebKit/")
+	b.WriteString("Mozilla/5.0 (")
+	iosWebKit(&id, iosVer, &b)
+	b.WriteString(") AppleWebKit/")
 	b.WriteString(webkitVersion)
 	b.WriteString(" (KHTML, like Gecko) Version/")
 	b.WriteString(safariVer)
 	b.WriteString(" Mobile/15E148 Safari/")
 	b.WriteString(webkitVersion)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
+// ChromeIOS generates a Chrome User-Agent for iPhone
 func (g *Generator) ChromeIOS() string {
-	iosVer := pick(g.rng, iosVersions)
-	chromeVer := pick(g.rng, chromeVersions)
+	return g.chromeIOS().UserAgent
+}
+
+func (g *Generator) chromeIOS() Identity {
+	g.refresh()
+	chromeVer := g.t.chrome.pick(g.rng)
+	iosVer := g.t.on(BrowserChrome, chromeVer).ios.pick(g.rng)
+	id := Identity{
+		Browser:        BrowserChrome,
+		BrowserVersion: chromeVer,
+		Engine:         EngineWebKit,
+		OS:             OSIOS,
+		OSVersion:      dotted(iosVer),
+		DeviceModel:    "iPhone",
+		Type:           TypeMobile,
+		DatasetID:      g.t.id,
+	}
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
 
-	b.WriteString("Mozilla/5.0 (iPhone; CPU iPhone OS ")
-	b.WriteString(iosVer)
-	b.WriteString(" like Mac OS X) AppleWebKit/")
+	b.WriteString("Mozilla/5.0 (")
+	iosWebKit(&id, iosVer, &b)
+	b.WriteString(") AppleWebKit/")
 	b.WriteString(appleWebKitChrome)
 	b.WriteString(" (KHTML, like Gecko) CriOS/")
 	b.WriteString(chromeVer)
 	b.WriteString(" Mobile/15E148 Safari/")
 	b.WriteString(appleWebKitChrome)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
+}
+
+// androidIdentity returns the Identity shared by Android browsers
+func (g *Generator) androidIdentity(browser Browser, version, androidVer string, device androidDevice) Identity {
+	return Identity{
+		Browser:        browser,
+		BrowserVersion: version,
+		Engine:         EngineBlink,
+		OS:             OSAndroid,
+		OSVersion:      androidVer,
+		DeviceModel:    device.model,
+		DeviceBuild:    device.build,
+		Type:           TypeMobile,
+		DatasetID:      g.t.id,
+	}
 }
 
+// ChromeAndroid generates a Chrome User-Agent for Android
 func (g *Generator) ChromeAndroid() string {
-	androidVer := pick(g.rng, androidVersions)
-	chromeVer := pick(g.rng, chromeVersions)
-	device := pick(g.rng, androidDevices)
+	return g.chromeAndroid().UserAgent
+}
+
+func (g *Generator) chromeAndroid() Identity {
+	g.refresh()
+	chromeVer := g.t.chrome.pick(g.rng)
+	device, androidVer := g.device(g.t.on(BrowserChrome, chromeVer))
+	id := g.androidIdentity(BrowserChrome, g.sentVersion(chromeVer), androidVer, device)
+	id.hints = chromium{
+		brand:           brandChrome,
+		brandVersion:    chromeVer,
+		version:         chromeVer,
+		platform:        OSAndroid,
+		platformVersion: androidVer + ".0.0",
+		model:           device.model,
+		mobile:          true,
+	}
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
 
-	b.WriteString("Mozilla/5.0 (Linux; Android ")
-	b.WriteString(androidVer)
-	b.WriteString("; ")
-	b.WriteString(device.model)
-	b.WriteString(" Build/")
-	b.WriteString(device.build)
+	b.WriteString("Mozilla/5.0 (")
+	g.androidChromium(&id, &b)
 	b.WriteString(") AppleWebKit/")
 	b.WriteString(appleWebKitChrome)
 	b.WriteString(" (KHTML, like Gecko) Chrome/")
-	b.WriteString(chromeVer)
+	b.WriteString(id.BrowserVersion)
 	b.WriteString(" Mobile Safari/")
 	b.WriteString(appleWebKitChrome)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
+// AndroidWebView generates an Android WebView User-Agent
 func (g *Generator) AndroidWebView() string {
-	androidVer := pick(g.rng, androidVersions)
-	chromeVer := pick(g.rng, chromeVersions)
-	device := pick(g.rng, androidDevices)
+	return g.androidWebView().UserAgent
+}
+
+func (g *Generator) androidWebView() Identity {
+	g.refresh()
+	chromeVer := g.t.chrome.pick(g.rng)
+	device, androidVer := g.device(g.t.on(BrowserChrome, chromeVer))
+	id := g.androidIdentity(BrowserWebView, chromeVer, androidVer, device)
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
@@ -104,12 +194,28 @@ func (g *Generator) AndroidWebView() string {
 	b.WriteString(" Mobile Safari/")
 	b.WriteString(appleWebKitChrome)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
+// FirefoxAndroid generates a Firefox User-Agent for Android
 func (g *Generator) FirefoxAndroid() string {
-	androidVer := pick(g.rng, androidVersions)
-	ffVer := pick(g.rng, firefoxVersions)
+	return g.firefoxAndroid().UserAgent
+}
+
+func (g *Generator) firefoxAndroid() Identity {
+	g.refresh()
+	ffVer := g.t.firefox.pick(g.rng)
+	androidVer := g.t.on(BrowserFirefox, ffVer).android.pick(g.rng)
+	id := Identity{
+		Browser:        BrowserFirefox,
+		BrowserVersion: ffVer,
+		Engine:         EngineGecko,
+		OS:             OSAndroid,
+		OSVersion:      androidVer,
+		Type:           TypeMobile,
+		DatasetID:      g.t.id,
+	}
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
@@ -117,19 +223,36 @@ func (g *Generator) FirefoxAndroid() string {
 	b.WriteString("Mozilla/5.0 (Android ")
 	b.WriteString(androidVer)
 	b.WriteString("; Mobile; rv:")
-	b.WriteString(ffVer)
+	b.WriteString(firefoxRV(ffVer))
 	b.WriteString(") Gecko/")
 	b.WriteString(ffVer)
 	b.WriteString(" Firefox/")
 	b.WriteString(ffVer)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
+// SamsungBrowser generates a Samsung Internet User-Agent
 func (g *Generator) SamsungBrowser() string {
-	androidVer := pick(g.rng, androidVersions)
-	chromeVer := pick(g.rng, chromeVersions)
-	device := pick(g.rng, androidDevices)
+	return g.samsungBrowser().UserAgent
+}
+
+func (g *Generator) samsungBrowser() Identity {
+	g.refresh()
+	samsungVer := g.t.samsung.pick(g.rng)
+	chromeVer := g.samsungChromium(samsungVer)
+	device, androidVer := g.device(g.t.on(BrowserSamsung, samsungVer))
+	id := g.androidIdentity(BrowserSamsung, samsungVer, androidVer, device)
+	id.hints = chromium{
+		brand:           brandSamsung,
+		brandVersion:    samsungVer,
+		version:         chromeVer,
+		platform:        OSAndroid,
+		platformVersion: androidVer + ".0.0",
+		model:           device.model,
+		mobile:          true,
+	}
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
@@ -142,29 +265,43 @@ func (g *Generator) SamsungBrowser() string {
 	b.WriteString(device.build)
 	b.WriteString(") AppleWebKit/")
 	b.WriteString(appleWebKitChrome)
-	b.WriteString(" (KHTML, like Gecko) SamsungBrowser/25.0 Chrome/")
+	b.WriteString(" (KHTML, like Gecko) SamsungBrowser/")
+	b.WriteString(samsungVer)
+	b.WriteString(" Chrome/")
 	b.WriteString(chromeVer)
 	b.WriteString(" Mobile Safari/")
 	b.WriteString(appleWebKitChrome)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
+// EdgeAndroid generates an Edge User-Agent for Android
 func (g *Generator) EdgeAndroid() string {
-	androidVer := pick(g.rng, androidVersions)
-	chromeVer := pick(g.rng, chromeVersions)
-	edgeVer := pick(g.rng, edgeVersions)
-	device := pick(g.rng, androidDevices)
+	return g.edgeAndroid().UserAgent
+}
+
+func (g *Generator) edgeAndroid() Identity {
+	g.refresh()
+	edgeVer := g.t.edge.pick(g.rng)
+	chromeVer := g.edgeChromium(edgeVer)
+	device, androidVer := g.device(g.t.on(BrowserEdge, edgeVer))
+	id := g.androidIdentity(BrowserEdge, g.sentVersion(edgeVer), androidVer, device)
+	id.hints = chromium{
+		brand:           brandEdge,
+		brandVersion:    edgeVer,
+		version:         chromeVer,
+		platform:        OSAndroid,
+		platformVersion: androidVer + ".0.0",
+		model:           device.model,
+		mobile:          true,
+	}
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
 
-	b.WriteString("Mozilla/5.0 (Linux; Android ")
-	b.WriteString(androidVer)
-	b.WriteString("; ")
-	b.WriteString(device.model)
-	b.WriteString(" Build/")
-	b.WriteString(device.build)
+	b.WriteString("Mozilla/5.0 (")
+	g.androidChromium(&id, &b)
 	b.WriteString(") AppleWebKit/")
 	b.WriteString(appleWebKitChrome)
 	b.WriteString(" (KHTML, like Gecko) Chrome/")
@@ -172,179 +309,170 @@ func (g *Generator) EdgeAndroid() string {
 	b.WriteString(" Mobile Safari/")
 	b.WriteString(appleWebKitChrome)
 	b.WriteString(" EdgA/")
-	b.WriteString(edgeVer)
+	b.WriteString(id.BrowserVersion)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
 // Browsers
 
-// Chrome generates a Chrome User-Agent for random desktop OS
-func (g *Generator) Chrome() string {
-	version := pick(g.rng, chromeVersions)
+// desktopIdentity returns the Identity shared by desktop browsers for
+// version picked from the data, sent as sent
+func (g *Generator) desktopIdentity(browser Browser, sent, version string, e Engine) Identity {
+	return Identity{
+		Browser:        browser,
+		BrowserVersion: sent,
+		Engine:         e,
+		Type:           TypeDesktop,
+		DatasetID:      g.t.id,
+		version:        version,
+	}
+}
+
+// chromeDesktop renders a Chrome desktop User-Agent for os
+func (g *Generator) chromeDesktop(os OS) Identity {
+	g.refresh()
+	version := g.t.chrome.pick(g.rng)
+	id := g.desktopIdentity(BrowserChrome, g.sentVersion(version), version, EngineBlink)
+	id.hints = chromium{brand: brandChrome, brandVersion: version, version: version}
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
 
 	b.WriteString("Mozilla/5.0 (")
-
-	switch g.rng.intn(3) {
-	case 0: // Windows
-		b.WriteString("Windows NT ")
-		b.WriteString(pick(g.rng, windowsVersions))
-		b.WriteString("; Win64; x64")
-	case 1: // macOS
-		b.WriteString("Macintosh; Intel Mac OS X ")
-		b.WriteString(pick(g.rng, macVersions))
-	case 2: // Linux
-		b.WriteString(pick(g.rng, linuxDesktops))
-	}
-
+	g.chromiumPlatform(os, &id, &b)
 	b.WriteString(") AppleWebKit/")
 	b.WriteString(appleWebKitChrome)
 	b.WriteString(" (KHTML, like Gecko) Chrome/")
-	b.WriteString(version)
+	b.WriteString(id.BrowserVersion)
 	b.WriteString(" Safari/")
 	b.WriteString(appleWebKitChrome)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
-// ChromeWindows generates a Chrome User-Agent for Windows
-func (g *Generator) ChromeWindows() string {
-	version := pick(g.rng, chromeVersions)
+// Chrome generates a Chrome User-Agent for random desktop OS
+func (g *Generator) Chrome() string {
+	return g.chrome().UserAgent
+}
 
-	var b strings.Builder
-	b.Grow(useragentBufSize)
+func (g *Generator) chrome() Identity {
+	switch g.rng.intn(3) {
+	case 0:
+		return g.chromeDesktop(OSWindows)
+	case 1:
+		return g.chromeDesktop(OSMacOS)
+	default:
+		return g.chromeDesktop(OSLinux)
+	}
+}
 
-	b.WriteString("Mozilla/5.0 (Windows NT ")
-	b.WriteString(pick(g.rng, windowsVersions))
-	b.WriteString("; Win64; x64) AppleWebKit/")
-	b.WriteString(appleWebKitChrome)
-	b.WriteString(" (KHTML, like Gecko) Chrome/")
-	b.WriteString(version)
-	b.WriteString(" Safari/")
-	b.WriteString(appleWebKitChrome)
+// ChromeWindows generates a Chrome User-Agent for Windows
+func (g *Generator) ChromeWindows() string {
+	return g.chromeWindows().UserAgent
+}
 
-	return b.String()
+func (g *Generator) chromeWindows() Identity {
+	return g.chromeDesktop(OSWindows)
 }
 
 // ChromeMac generates a Chrome User-Agent for macOS
 func (g *Generator) ChromeMac() string {
-	version := pick(g.rng, chromeVersions)
-
-	var b strings.Builder
-	b.Grow(useragentBufSize)
-
-	b.WriteString("Mozilla/5.0 (Macintosh; Intel Mac OS X ")
-	b.WriteString(pick(g.rng, macVersions))
-	b.WriteString(") AppleWebKit/")
-	b.WriteString(appleWebKitChrome)
-	b.WriteString(" (KHTML, like Gecko) Chrome/")
-	b.WriteString(version)
-	b.WriteString(" Safari/")
-	b.WriteString(appleWebKitChrome)
+	return g.chromeMac().UserAgent
+}
 
-	return b.String()
+func (g *Generator) chromeMac() Identity {
+	return g.chromeDesktop(OSMacOS)
 }
 
 // ChromeLinux generates a Chrome User-Agent for Linux
 func (g *Generator) ChromeLinux() string {
-	version := pick(g.rng, chromeVersions)
+	return g.chromeLinux().UserAgent
+}
+
+func (g *Generator) chromeLinux() Identity {
+	return g.chromeDesktop(OSLinux)
+}
+
+func (g *Generator) chromeOS() Identity {
+	return g.chromeDesktop(OSChromeOS)
+}
+
+// firefoxDesktop renders a Firefox desktop User-Agent for os
+func (g *Generator) firefoxDesktop(os OS) Identity {
+	g.refresh()
+	version := g.t.firefox.pick(g.rng)
+	id := g.desktopIdentity(BrowserFirefox, version, version, EngineGecko)
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
 
 	b.WriteString("Mozilla/5.0 (")
-	b.WriteString(pick(g.rng, linuxDesktops))
-	b.WriteString(") AppleWebKit/")
-	b.WriteString(appleWebKitChrome)
-	b.WriteString(" (KHTML, like Gecko) Chrome/")
+	g.firefoxPlatform(os, &id, &b)
+	b.WriteString("; rv:")
+	b.WriteString(firefoxRV(version))
+	b.WriteString(") Gecko/20100101 Firefox/")
 	b.WriteString(version)
-	b.WriteString(" Safari/")
-	b.WriteString(appleWebKitChrome)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
 // Firefox generates a Firefox desktop User-Agent
 func (g *Generator) Firefox() string {
-	version := pick(g.rng, firefoxVersions)
-
-	var b strings.Builder
-	b.Grow(useragentBufSize)
-
-	b.WriteString("Mozilla/5.0 (")
+	return g.firefox().UserAgent
+}
 
+func (g *Generator) firefox() Identity {
 	switch g.rng.intn(3) {
-	case 0: // Windows
-		b.WriteString("Windows NT ")
-		b.WriteString(pick(g.rng, windowsVersions))
-		b.WriteString("; Win64; x64; rv:")
-		b.WriteString(version)
-		b.WriteString(") Gecko/20100101 Firefox/")
-	case 1: // macOS
-		b.WriteString("Macintosh; Intel Mac OS X ")
-		b.WriteString(pick(g.rng, macVersions))
-		b.WriteString("; rv:")
-		b.WriteString(version)
-		b.WriteString(") Gecko/20100101 Firefox/")
-	case 2: // Linux
-		b.WriteString(pick(g.rng, linuxDesktops))
-		b.WriteString("; rv:")
-		b.WriteString(version)
-		b.WriteString(") Gecko/20100101 Firefox/")
+	case 0:
+		return g.firefoxDesktop(OSWindows)
+	case 1:
+		return g.firefoxDesktop(OSMacOS)
+	default:
+		return g.firefoxDesktop(OSLinux)
 	}
-
-	b.WriteString(version)
-
-	return b.String()
 }
 
 // FirefoxWindows generates a Firefox User-Agent for Windows
 func (g *Generator) FirefoxWindows() string {
-	version := pick(g.rng, firefoxVersions)
-
-	var b strings.Builder
-	b.Grow(useragentBufSize)
-
-	b.WriteString("Mozilla/5.0 (Windows NT ")
-	b.WriteString(pick(g.rng, windowsVersions))
-	b.WriteString("; Win64; x64; rv:")
-	b.WriteString(version)
-	b.WriteString(") Gecko/20100101 Firefox/")
-	b.WriteString(version)
+	return g.firefoxWindows().UserAgent
+}
 
-	return b.String()
+func (g *Generator) firefoxWindows() Identity {
+	return g.firefoxDesktop(OSWindows)
 }
 
 // FirefoxMac generates a Firefox User-Agent for macOS
 func (g *Generator) FirefoxMac() string {
-	version := pick(g.rng, firefoxVersions)
-
-	var b strings.Builder
-	b.Grow(useragentBufSize)
+	return g.firefoxMac().UserAgent
+}
 
-	b.WriteString("Mozilla/5.0 (Macintosh; Intel Mac OS X ")
-	b.WriteString(pick(g.rng, macVersions))
-	b.WriteString("; rv:")
-	b.WriteString(version)
-	b.WriteString(") Gecko/20100101 Firefox/")
-	b.WriteString(version)
+func (g *Generator) firefoxMac() Identity {
+	return g.firefoxDesktop(OSMacOS)
+}
 
-	return b.String()
+func (g *Generator) firefoxLinux() Identity {
+	return g.firefoxDesktop(OSLinux)
 }
 
 // Safari generates a Safari desktop User-Agent (macOS only)
 func (g *Generator) Safari() string {
-	version := pick(g.rng, safariVersions)
-	macVer := pick(g.rng, macVersions)
+	return g.safari().UserAgent
+}
+
+func (g *Generator) safari() Identity {
+	g.refresh()
+	version := g.t.safari.pick(g.rng)
+	id := g.desktopIdentity(BrowserSafari, version, version, EngineWebKit)
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
 
-	b.WriteString("Mozilla/5.0 (Macintosh; Intel Mac OS X ")
-	b.WriteString(macVer)
+	b.WriteString("Mozilla/5.0 (")
+	g.macWebKit(&id, &b)
 	b.WriteString(") AppleWebKit/")
 	b.WriteString(webkitVersion)
 	b.WriteString(" (KHTML, like Gecko) Version/")
@@ -352,29 +480,23 @@ func (g *Generator) Safari() string {
 	b.WriteString(" Safari/")
 	b.WriteString(webkitVersion)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
 }
 
-// Edge generates an Edge desktop User-Agent
-func (g *Generator) Edge() string {
-	edgeVer := pick(g.rng, edgeVersions)
-	chromeVer := pick(g.rng, chromeVersions)
+// edgeDesktop renders an Edge desktop User-Agent for os
+func (g *Generator) edgeDesktop(os OS) Identity {
+	g.refresh()
+	edgeVer := g.t.edge.pick(g.rng)
+	chromeVer := g.edgeChromium(edgeVer)
+	id := g.desktopIdentity(BrowserEdge, g.sentVersion(edgeVer), edgeVer, EngineBlink)
+	id.hints = chromium{brand: brandEdge, brandVersion: edgeVer, version: chromeVer}
 
 	var b strings.Builder
 	b.Grow(useragentBufSize)
 
 	b.WriteString("Mozilla/5.0 (")
-
-	switch g.rng.intn(2) {
-	case 0: // Windows
-		b.WriteString("Windows NT ")
-		b.WriteString(pick(g.rng, windowsVersions))
-		b.WriteString("; Win64; x64")
-	case 1: // macOS
-		b.WriteString("Macintosh; Intel Mac OS X ")
-		b.WriteString(pick(g.rng, macVersions))
-	}
-
+	g.chromiumPlatform(os, &id, &b)
 	b.WriteString(") AppleWebKit/")
 	b.WriteString(appleWebKitChrome)
 	b.WriteString(" (KHTML, like Gecko) Chrome/")
@@ -382,31 +504,37 @@ func (g *Generator) Edge() string {
 	b.WriteString(" Safari/")
 	b.WriteString(appleWebKitChrome)
 	b.WriteString(" Edg/")
-	b.WriteString(edgeVer)
+	b.WriteString(id.BrowserVersion)
 
-	return b.String()
+	id.UserAgent = b.String()
+	return id
+}
+
+// Edge generates an Edge desktop User-Agent
+func (g *Generator) Edge() string {
+	return g.edge().UserAgent
+}
+
+func (g *Generator) edge() Identity {
+	switch g.rng.intn(2) {
+	case 0:
+		return g.edgeDesktop(OSWindows)
+	default:
+		return g.edgeDesktop(OSMacOS)
+	}
 }
 
 // EdgeWindows generates an Edge User-Agent for Windows
 func (g *Generator) EdgeWindows() string {
-	edgeVer := pick(g.rng, edgeVersions)
-	chromeVer := pick(g.rng, chromeVersions)
-
-	var b strings.Builder
-	b.Grow(useragentBufSize)
+	return g.edgeWindows().UserAgent
+}
 
-	b.WriteString("Mozilla/5.0 (Windows NT ")
-	b.WriteString(pick(g.rng, windowsVersions))
-	b.WriteString("; Win64; x64) AppleWebKit/")
-	b.WriteString(appleWebKitChrome)
-	b.WriteString(" (KHTML, like Gecko) Chrome/")
-	b.WriteString(chromeVer)
-	b.WriteString(" Safari/")
-	b.WriteString(appleWebKitChrome)
-	b.WriteString(" Edg/")
-	b.WriteString(edgeVer)
+func (g *Generator) edgeWindows() Identity {
+	return g.edgeDesktop(OSWindows)
+}
 
-	return b.String()
+func (g *Generator) edgeMac() Identity {
+	return g.edgeDesktop(OSMacOS)
 }
 
 // Search engine bots
@@ -0,0 +1,258 @@
+package ua
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ClientHints holds the low-entropy User-Agent Client Hints that
+// Chromium-based browsers send with every request.
+// Values are already formatted as HTTP structured header values.
+type ClientHints struct {
+	UA       string // Sec-CH-UA, e.g. `"Chromium";v="142", "Google Chrome";v="142", "Not_A Brand";v="99"`
+	Mobile   string // Sec-CH-UA-Mobile, "?0" or "?1"
+	Platform string // Sec-CH-UA-Platform, e.g. `"Windows"`
+
+	src chromium
+}
+
+// Set writes the hints into h, replacing any existing values
+func (c ClientHints) Set(h http.Header) {
+	h.Set("Sec-CH-UA", c.UA)
+	h.Set("Sec-CH-UA-Mobile", c.Mobile)
+	h.Set("Sec-CH-UA-Platform", c.Platform)
+}
+
+// Brand names as reported in Sec-CH-UA
+const (
+	brandChromium = "Chromium"
+	brandChrome   = "Google Chrome"
+	brandEdge     = "Microsoft Edge"
+	brandSamsung  = "Samsung Internet"
+)
+
+// HighEntropyHints holds the User-Agent Client Hints a Chromium-based
+// browser only sends after the server asks for them with Accept-CH.
+// Values are already formatted as HTTP structured header values.
+type HighEntropyHints struct {
+	FullVersionList string // Sec-CH-UA-Full-Version-List
+	PlatformVersion string // Sec-CH-UA-Platform-Version, e.g. `"15.0.0"`
+	Arch            string // Sec-CH-UA-Arch, e.g. `"x86"`
+	Bitness         string // Sec-CH-UA-Bitness, e.g. `"64"`
+	Model           string // Sec-CH-UA-Model, e.g. `"Pixel 8"`, `""` on desktop
+	WoW64           string // Sec-CH-UA-WoW64, "?0" or "?1"
+}
+
+// Set writes the hints into h, replacing any existing values
+func (c HighEntropyHints) Set(h http.Header) {
+	h.Set("Sec-CH-UA-Full-Version-List", c.FullVersionList)
+	h.Set("Sec-CH-UA-Platform-Version", c.PlatformVersion)
+	h.Set("Sec-CH-UA-Arch", c.Arch)
+	h.Set("Sec-CH-UA-Bitness", c.Bitness)
+	h.Set("Sec-CH-UA-Model", c.Model)
+	h.Set("Sec-CH-UA-WoW64", c.WoW64)
+}
+
+// HighEntropy returns the high-entropy hints matching c
+func (c ClientHints) HighEntropy() HighEntropyHints {
+	h := HighEntropyHints{
+		FullVersionList: formatBrands(c.src.brands(true)),
+		PlatformVersion: strconv.Quote(c.src.platformVersion),
+		Arch:            strconv.Quote(c.src.arch),
+		Bitness:         strconv.Quote(c.src.bitness),
+		Model:           strconv.Quote(c.src.model),
+		WoW64:           "?0",
+	}
+	if c.src.wow64 {
+		h.WoW64 = "?1"
+	}
+	return h
+}
+
+// UserAgentData mirrors the object resolved by
+// navigator.userAgentData.getHighEntropyValues() in JavaScript.
+// Marshal it with encoding/json to override navigator.userAgentData
+// in a headless browser.
+type UserAgentData struct {
+	Architecture    string         `json:"architecture"`
+	Bitness         string         `json:"bitness"`
+	Brands          []BrandVersion `json:"brands"`
+	FullVersionList []BrandVersion `json:"fullVersionList"`
+	Mobile          bool           `json:"mobile"`
+	Model           string         `json:"model"`
+	Platform        string         `json:"platform"`
+	PlatformVersion string         `json:"platformVersion"`
+	UAFullVersion   string         `json:"uaFullVersion"`
+	WoW64           bool           `json:"wow64"`
+}
+
+// UserAgentData returns the navigator.userAgentData values matching c
+func (c ClientHints) UserAgentData() UserAgentData {
+	brands := c.src.brands(false)
+	full := c.src.brands(true)
+	return UserAgentData{
+		Architecture:    c.src.arch,
+		Bitness:         c.src.bitness,
+		Brands:          brands[:],
+		FullVersionList: full[:],
+		Mobile:          c.src.mobile,
+		Model:           c.src.model,
+		Platform:        string(c.src.platform),
+		PlatformVersion: c.src.platformVersion,
+		UAFullVersion:   c.src.fullBrandVersion(),
+		WoW64:           c.src.wow64,
+	}
+}
+
+// chromium records the choices behind a Chromium-based User-Agent,
+// so the string and its client hints always agree.
+type chromium struct {
+	brand           string // branded browser, e.g. brandEdge
+	brandVersion    string // full version of the branded browser
+	version         string // full Chromium version
+	platform        OS
+	platformVersion string // e.g. "15.0.0" for Windows 11, "14.4.0" for macOS
+	arch            string // "x86", "arm" or "" on Android
+	bitness         string // "64", "32" or "" on Android
+	model           string // device model, empty on desktop
+	mobile          bool
+	wow64           bool
+}
+
+// BrandVersion is one entry of a Sec-CH-UA brand list
+type BrandVersion struct {
+	Brand   string `json:"brand"`
+	Version string `json:"version"`
+}
+
+// brands returns the brand list in the order Chrome sends it.
+// With full set, versions are full versions (Sec-CH-UA-Full-Version-List),
+// otherwise significant versions (Sec-CH-UA).
+func (c *chromium) brands(full bool) [3]BrandVersion {
+	chromiumVer, brandVer := c.version, c.brandVersion
+	if full {
+		chromiumVer, brandVer = fullVersion(chromiumVer, chromiumBuilds), c.fullBrandVersion()
+	} else {
+		chromiumVer = majorVersion(chromiumVer)
+		if c.brand != brandSamsung { // Samsung reports major.minor
+			brandVer = majorVersion(brandVer)
+		}
+	}
+
+	// Chromium seeds GREASE and the permutation with its major version,
+	// see GenerateBrandVersionList in components/embedder_support/user_agent_utils.cc
+	seed, _ := strconv.Atoi(majorVersion(c.version))
+	order := greaseOrders[seed%len(greaseOrders)]
+
+	var list [3]BrandVersion
+	list[order[0]] = greaseBrand(seed, full)
+	list[order[1]] = BrandVersion{brandChromium, chromiumVer}
+	list[order[2]] = BrandVersion{c.brand, brandVer}
+	return list
+}
+
+// fullBrandVersion returns the full version of the branded browser
+func (c *chromium) fullBrandVersion() string {
+	switch c.brand {
+	case brandChrome:
+		return fullVersion(c.brandVersion, chromiumBuilds)
+	case brandEdge:
+		return fullVersion(c.brandVersion, edgeBuilds)
+	}
+	return c.brandVersion
+}
+
+// hints returns the low-entropy client hints for c
+func (c *chromium) hints() ClientHints {
+	h := ClientHints{
+		UA:       formatBrands(c.brands(false)),
+		Mobile:   "?0",
+		Platform: strconv.Quote(string(c.platform)),
+		src:      *c,
+	}
+	if c.mobile {
+		h.Mobile = "?1"
+	}
+	return h
+}
+
+var (
+	greaseChars    = []string{" ", "(", ":", "-", ".", "/", ")", ";", "=", "?", "_"}
+	greaseVersions = []string{"8", "99", "24"}
+	greaseOrders   = [][3]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+)
+
+// greaseBrand returns the GREASE brand Chrome generates for a major version
+func greaseBrand(seed int, full bool) BrandVersion {
+	brand := "Not" + greaseChars[seed%len(greaseChars)] +
+		"A" + greaseChars[(seed+1)%len(greaseChars)] + "Brand"
+	version := greaseVersions[seed%len(greaseVersions)]
+	if full {
+		version += ".0.0.0"
+	}
+	return BrandVersion{brand, version}
+}
+
+// formatBrands renders a brand list as a structured header list
+func formatBrands(list [3]BrandVersion) string {
+	var b strings.Builder
+	for i, bv := range list {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Quote(bv.Brand))
+		b.WriteString(";v=")
+		b.WriteString(strconv.Quote(bv.Version))
+	}
+	return b.String()
+}
+
+// majorVersion returns the part of v before the first dot
+func majorVersion(v string) string {
+	if i := strings.IndexByte(v, '.'); i >= 0 {
+		return v[:i]
+	}
+	return v
+}
+
+// majorNumber returns the major version of v, 0 if it is not a number
+func majorNumber(v string) int {
+	n, _ := strconv.Atoi(majorVersion(v))
+	return n
+}
+
+// ChromeWithHints generates a Chrome desktop User-Agent together with
+// the client hints Chrome would send alongside it
+func (g *Generator) ChromeWithHints() (string, ClientHints) {
+	id := g.chrome()
+	return id.UserAgent, id.hints.hints()
+}
+
+// ChromeAndroidWithHints generates a Chrome Android User-Agent together
+// with the client hints Chrome would send alongside it
+func (g *Generator) ChromeAndroidWithHints() (string, ClientHints) {
+	id := g.chromeAndroid()
+	return id.UserAgent, id.hints.hints()
+}
+
+// EdgeWithHints generates an Edge desktop User-Agent together with
+// the client hints Edge would send alongside it
+func (g *Generator) EdgeWithHints() (string, ClientHints) {
+	id := g.edge()
+	return id.UserAgent, id.hints.hints()
+}
+
+// EdgeAndroidWithHints generates an Edge Android User-Agent together
+// with the client hints Edge would send alongside it
+func (g *Generator) EdgeAndroidWithHints() (string, ClientHints) {
+	id := g.edgeAndroid()
+	return id.UserAgent, id.hints.hints()
+}
+
+// SamsungBrowserWithHints generates a Samsung Internet User-Agent together
+// with the client hints Samsung Internet would send alongside it
+func (g *Generator) SamsungBrowserWithHints() (string, ClientHints) {
+	id := g.samsungBrowser()
+	return id.UserAgent, id.hints.hints()
+}
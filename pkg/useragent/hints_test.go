@@ -0,0 +1,210 @@
+package ua
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBrandsMatchRealChrome(t *testing.T) {
+	// Sec-CH-UA values captured from real Chrome releases
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"120.0.0.0", `"Not_A Brand";v="8", "Chromium";v="120", "Google Chrome";v="120"`},
+		{"124.0.0.0", `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`},
+		{"131.0.0.0", `"Google Chrome";v="131", "Chromium";v="131", "Not_A Brand";v="24"`},
+		{"138.0.0.0", `"Not)A;Brand";v="8", "Chromium";v="138", "Google Chrome";v="138"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			c := chromium{brand: brandChrome, brandVersion: tt.version, version: tt.version}
+			if got := c.hints().UA; got != tt.want {
+				t.Errorf("Sec-CH-UA = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFullVersionBrands(t *testing.T) {
+	c := chromium{brand: brandEdge, brandVersion: "131.0.2903.51", version: "131.0.6778.85"}
+	got := formatBrands(c.brands(true))
+	want := `"Microsoft Edge";v="131.0.2903.51", "Chromium";v="131.0.6778.85", "Not_A Brand";v="24.0.0.0"`
+	if got != want {
+		t.Errorf("full version list = %s, want %s", got, want)
+	}
+}
+
+func TestFullVersionsOfReducedBrands(t *testing.T) {
+	c := chromium{brand: brandEdge, brandVersion: "142.0.0.0", version: "142.0.0.0"}
+	full := c.brands(true)
+	for _, bv := range full {
+		if bv.Brand != brandChromium && bv.Brand != brandEdge {
+			continue
+		}
+		if !fullBuild(bv.Version) {
+			t.Errorf("%s full version %s is reduced", bv.Brand, bv.Version)
+		}
+	}
+	data := c.hints().UserAgentData()
+	if want := fullVersion("142.0.0.0", edgeBuilds); data.UAFullVersion != want || !strings.HasPrefix(want, "142.0.3595.") {
+		t.Errorf("uaFullVersion = %s, want the Edge version %s", data.UAFullVersion, want)
+	}
+	if got := formatBrands(full); !strings.Contains(got, `"Chromium";v="142.0.7444.`) {
+		t.Errorf("full version list %s, want Chromium 142.0.7444", got)
+	}
+
+	// Majors without a known branch keep their version
+	if got := fullVersion("150.0.0.0", chromiumBuilds); got != "150.0.0.0" {
+		t.Errorf("fullVersion(150.0.0.0) = %s", got)
+	}
+}
+
+func TestHintsAgreeWithUA(t *testing.T) {
+	g := WithSeed(42)
+
+	chromeRe := regexp.MustCompile(`Chrome/(\d+)\.`)
+	brandRe := regexp.MustCompile(`"Chromium";v="(\d+)"`)
+
+	generators := []struct {
+		name   string
+		fn     func() (string, ClientHints)
+		brand  string
+		token  *regexp.Regexp
+		mobile string
+	}{
+		{"Chrome", g.ChromeWithHints, "Google Chrome", chromeRe, "?0"},
+		{"ChromeAndroid", g.ChromeAndroidWithHints, "Google Chrome", chromeRe, "?1"},
+		{"Edge", g.EdgeWithHints, "Microsoft Edge", regexp.MustCompile(`Edg/(\d+)\.`), "?0"},
+		{"EdgeAndroid", g.EdgeAndroidWithHints, "Microsoft Edge", regexp.MustCompile(`EdgA/(\d+)\.`), "?1"},
+		{"SamsungBrowser", g.SamsungBrowserWithHints, "Samsung Internet", regexp.MustCompile(`SamsungBrowser/(\d+\.\d+)`), "?1"},
+	}
+
+	for _, gen := range generators {
+		t.Run(gen.name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				ua, h := gen.fn()
+
+				if want := brandRe.FindStringSubmatch(h.UA); want == nil || chromeRe.FindStringSubmatch(ua)[1] != want[1] {
+					t.Errorf("Chromium brand %q disagrees with %s", h.UA, ua)
+				}
+				if want := `"` + gen.brand + `";v="` + gen.token.FindStringSubmatch(ua)[1] + `"`; !strings.Contains(h.UA, want) {
+					t.Errorf("Sec-CH-UA %s missing %s for %s", h.UA, want, ua)
+				}
+				if h.Mobile != gen.mobile {
+					t.Errorf("Sec-CH-UA-Mobile = %s, want %s", h.Mobile, gen.mobile)
+				}
+
+				var platform string
+				switch {
+				case strings.Contains(ua, "Android"):
+					platform = `"Android"`
+				case strings.Contains(ua, "Windows"):
+					platform = `"Windows"`
+				case strings.Contains(ua, "Macintosh"):
+					platform = `"macOS"`
+				default:
+					platform = `"Linux"`
+				}
+				if h.Platform != platform {
+					t.Errorf("Sec-CH-UA-Platform = %s, want %s for %s", h.Platform, platform, ua)
+				}
+			}
+		})
+	}
+}
+
+func TestClientHintsSet(t *testing.T) {
+	_, hints := ChromeWithHints()
+
+	h := make(http.Header)
+	hints.Set(h)
+
+	if h.Get("Sec-CH-UA") != hints.UA || h.Get("Sec-CH-UA-Mobile") != hints.Mobile ||
+		h.Get("Sec-CH-UA-Platform") != hints.Platform {
+		t.Errorf("Set() wrote %v, want %+v", h, hints)
+	}
+}
+
+func TestHighEntropyHints(t *testing.T) {
+	g := WithSeed(42, WithLegacyUA())
+
+	for i := 0; i < 200; i++ {
+		id := g.ChromeIdentity()
+		hints, _ := id.ClientHints()
+		he := hints.HighEntropy()
+
+		switch {
+		case id.OS == OSWindows && id.OSVersion == "10.0":
+			if he.PlatformVersion == `"0.1.0"` || he.PlatformVersion == `"0.2.0"` {
+				t.Errorf("Windows 10/11 with Windows 7/8 platform version: %s", he.PlatformVersion)
+			}
+		case id.OS == OSWindows && id.OSVersion == "6.1":
+			if he.PlatformVersion != `"0.1.0"` {
+				t.Errorf("Windows 7 with platform version %s", he.PlatformVersion)
+			}
+		case id.OS == OSMacOS:
+			if want := strconv.Quote(dottedVersion(strings.ReplaceAll(id.OSVersion, ".", "_"))); he.PlatformVersion != want {
+				t.Errorf("macOS %s with platform version %s, want %s", id.OSVersion, he.PlatformVersion, want)
+			}
+		case strings.Contains(id.UserAgent, "aarch64"):
+			if he.Arch != `"arm"` {
+				t.Errorf("aarch64 UA with arch %s", he.Arch)
+			}
+		}
+		if he.Model != `""` {
+			t.Errorf("desktop UA with model %s", he.Model)
+		}
+	}
+}
+
+func TestHighEntropyHintsAndroid(t *testing.T) {
+	g := WithSeed(42, WithLegacyUA())
+	androidRe := regexp.MustCompile(`Android (\d+); (.+) Build/`)
+
+	for i := 0; i < 50; i++ {
+		ua, hints := g.ChromeAndroidWithHints()
+		he := hints.HighEntropy()
+		m := androidRe.FindStringSubmatch(ua)
+		if m == nil {
+			t.Fatalf("legacy Android UA without device: %s", ua)
+		}
+
+		if want := strconv.Quote(m[2]); he.Model != want {
+			t.Errorf("model %s, want %s for %s", he.Model, want, ua)
+		}
+		if want := `"` + m[1] + `.0.0"`; he.PlatformVersion != want {
+			t.Errorf("platform version %s, want %s for %s", he.PlatformVersion, want, ua)
+		}
+	}
+}
+
+func TestUserAgentDataJSON(t *testing.T) {
+	c := chromium{
+		brand:           brandChrome,
+		brandVersion:    "138.0.0.0",
+		version:         "138.0.0.0",
+		platform:        OSWindows,
+		platformVersion: "19.0.0",
+		arch:            "x86",
+		bitness:         "64",
+	}
+
+	data, err := json.Marshal(c.hints().UserAgentData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"architecture":"x86","bitness":"64",` +
+		`"brands":[{"brand":"Not)A;Brand","version":"8"},{"brand":"Chromium","version":"138"},{"brand":"Google Chrome","version":"138"}],` +
+		`"fullVersionList":[{"brand":"Not)A;Brand","version":"8.0.0.0"},{"brand":"Chromium","version":"138.0.7204.105"},{"brand":"Google Chrome","version":"138.0.7204.105"}],` +
+		`"mobile":false,"model":"","platform":"Windows","platformVersion":"19.0.0","uaFullVersion":"138.0.7204.105","wow64":false}`
+	if string(data) != want {
+		t.Errorf("JSON =\n%s\nwant\n%s", data, want)
+	}
+}
@@ -0,0 +1,174 @@
+package ua
+
+import "strings"
+
+// Identity is a generated User-Agent together with the choices it was
+// built from. Fields use the same formats as Parse. Browsers that freeze
+// parts of the User-Agent (see WithLegacyUA) send fixed values instead of
+// the real OS version and device model; Identity keeps the real ones,
+// which the browser still exposes through client hints.
+type Identity struct {
+	UserAgent      string
+	Browser        Browser
+	BrowserVersion string // as sent, e.g. "142.0.0.0", "146.0", "18.6"
+	Engine         Engine
+	OS             OS
+	OSVersion      string // dotted, e.g. "10.0" (Windows NT), "10.15.7", "17.4.1", "14"
+	DeviceModel    string // e.g. "SM-S911B", "iPhone", "iPad"
+	DeviceBuild    string // Android build ID, e.g. "UP1A.231005.007"
+	Type           UAType // device class
+	Bot            string // bot name for crawlers, e.g. "Googlebot"
+	Reduced        bool   // User-Agent sends frozen or shortened OS or device values
+	DatasetID      string // Dataset.ID of the data it was sampled from, empty for bots
+
+	hints   chromium // zero for browsers without client hints
+	version string   // BrowserVersion as picked from the data, before reduction
+}
+
+// ClientHints returns the client hints the browser sends alongside the
+// User-Agent. ok is false for browsers that do not send them.
+func (id Identity) ClientHints() (hints ClientHints, ok bool) {
+	if id.hints.brand == "" {
+		return ClientHints{}, false
+	}
+	return id.hints.hints(), true
+}
+
+// dotted converts an underscore version ("10_15_7") to dotted form
+func dotted(v string) string {
+	return strings.ReplaceAll(v, "_", ".")
+}
+
+// Bot identities, parsed once from botUAs
+var botIdentities = func() []Identity {
+	ids := make([]Identity, len(botUAs))
+	for i, s := range botUAs {
+		u := Parse(s)
+		ids[i] = Identity{
+			UserAgent:      s,
+			Browser:        u.Browser,
+			BrowserVersion: u.BrowserVersion,
+			Engine:         u.Engine,
+			OS:             u.OS,
+			OSVersion:      u.OSVersion,
+			DeviceModel:    u.DeviceModel,
+			Type:           u.Type,
+			Bot:            u.Bot,
+		}
+	}
+	return ids
+}()
+
+// ChromeIdentity generates a Chrome desktop Identity
+func (g *Generator) ChromeIdentity() Identity { return g.chrome() }
+
+// ChromeWindowsIdentity generates a Chrome Identity for Windows
+func (g *Generator) ChromeWindowsIdentity() Identity { return g.chromeWindows() }
+
+// ChromeMacIdentity generates a Chrome Identity for macOS
+func (g *Generator) ChromeMacIdentity() Identity { return g.chromeMac() }
+
+// ChromeLinuxIdentity generates a Chrome Identity for Linux
+func (g *Generator) ChromeLinuxIdentity() Identity { return g.chromeLinux() }
+
+// FirefoxIdentity generates a Firefox desktop Identity
+func (g *Generator) FirefoxIdentity() Identity { return g.firefox() }
+
+// FirefoxWindowsIdentity generates a Firefox Identity for Windows
+func (g *Generator) FirefoxWindowsIdentity() Identity { return g.firefoxWindows() }
+
+// FirefoxMacIdentity generates a Firefox Identity for macOS
+func (g *Generator) FirefoxMacIdentity() Identity { return g.firefoxMac() }
+
+// SafariIdentity generates a Safari desktop Identity
+func (g *Generator) SafariIdentity() Identity { return g.safari() }
+
+// EdgeIdentity generates an Edge desktop Identity
+func (g *Generator) EdgeIdentity() Identity { return g.edge() }
+
+// EdgeWindowsIdentity generates an Edge Identity for Windows
+func (g *Generator) EdgeWindowsIdentity() Identity { return g.edgeWindows() }
+
+// SafariIOSIdentity generates a Safari Identity for iPhone
+func (g *Generator) SafariIOSIdentity() Identity { return g.safariIOS() }
+
+// SafariIPadIdentity generates a Safari Identity for iPad
+func (g *Generator) SafariIPadIdentity() Identity { return g.safariIPad() }
+
+// ChromeIOSIdentity generates a Chrome Identity for iPhone
+func (g *Generator) ChromeIOSIdentity() Identity { return g.chromeIOS() }
+
+// ChromeAndroidIdentity generates a Chrome Identity for Android
+func (g *Generator) ChromeAndroidIdentity() Identity { return g.chromeAndroid() }
+
+// AndroidWebViewIdentity generates an Android WebView Identity
+func (g *Generator) AndroidWebViewIdentity() Identity { return g.androidWebView() }
+
+// FirefoxAndroidIdentity generates a Firefox Identity for Android
+func (g *Generator) FirefoxAndroidIdentity() Identity { return g.firefoxAndroid() }
+
+// SamsungBrowserIdentity generates a Samsung Internet Identity
+func (g *Generator) SamsungBrowserIdentity() Identity { return g.samsungBrowser() }
+
+// EdgeAndroidIdentity generates an Edge Identity for Android
+func (g *Generator) EdgeAndroidIdentity() Identity { return g.edgeAndroid() }
+
+// RandomIdentity returns a random Identity from any category.
+// It consumes the generator exactly like Random, so equally seeded
+// generators return the same User-Agent from either.
+func (g *Generator) RandomIdentity() Identity {
+	category := UAType(g.rng.intn(3)) // TypeDesktop, TypeMobile or TypeBot
+	if g.mix != nil {
+		category = g.mix.category.pick(g.rng)
+	}
+
+	switch category {
+	case TypeDesktop:
+		return g.RandomDesktopIdentity()
+	case TypeMobile:
+		return g.RandomMobileIdentity()
+	default:
+		return g.RandomBotIdentity()
+	}
+}
+
+// RandomDesktopIdentity returns a random desktop browser Identity
+func (g *Generator) RandomDesktopIdentity() Identity {
+	if g.mix != nil {
+		return g.mix.desktop.pick(g.rng)(g)
+	}
+
+	switch g.rng.intn(4) {
+	case 0:
+		return g.chrome()
+	case 1:
+		return g.firefox()
+	case 2:
+		return g.safari()
+	default:
+		return g.edge()
+	}
+}
+
+// RandomMobileIdentity returns a random mobile browser Identity
+func (g *Generator) RandomMobileIdentity() Identity {
+	if g.mix != nil {
+		return g.mix.mobile.pick(g.rng)(g)
+	}
+
+	switch g.rng.intn(4) {
+	case 0:
+		return g.safariIOS()
+	case 1:
+		return g.chromeAndroid()
+	case 2:
+		return g.chromeIOS()
+	default:
+		return g.androidWebView()
+	}
+}
+
+// RandomBotIdentity returns a random bot Identity
+func (g *Generator) RandomBotIdentity() Identity {
+	return botIdentities[g.rng.intn(len(botIdentities))]
+}
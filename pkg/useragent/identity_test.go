@@ -0,0 +1,81 @@
+package ua
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIdentityMatchesParse(t *testing.T) {
+	g := WithSeed(42)
+
+	generators := map[string]func() Identity{
+		"Chrome":         g.ChromeIdentity,
+		"ChromeWindows":  g.ChromeWindowsIdentity,
+		"ChromeMac":      g.ChromeMacIdentity,
+		"ChromeLinux":    g.ChromeLinuxIdentity,
+		"Firefox":        g.FirefoxIdentity,
+		"FirefoxWindows": g.FirefoxWindowsIdentity,
+		"FirefoxMac":     g.FirefoxMacIdentity,
+		"Safari":         g.SafariIdentity,
+		"Edge":           g.EdgeIdentity,
+		"EdgeWindows":    g.EdgeWindowsIdentity,
+		"SafariIOS":      g.SafariIOSIdentity,
+		"SafariIPad":     g.SafariIPadIdentity,
+		"ChromeIOS":      g.ChromeIOSIdentity,
+		"ChromeAndroid":  g.ChromeAndroidIdentity,
+		"AndroidWebView": g.AndroidWebViewIdentity,
+		"FirefoxAndroid": g.FirefoxAndroidIdentity,
+		"SamsungBrowser": g.SamsungBrowserIdentity,
+		"EdgeAndroid":    g.EdgeAndroidIdentity,
+		"Random":         g.RandomIdentity,
+		"RandomBot":      g.RandomBotIdentity,
+	}
+
+	for name, fn := range generators {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				id := fn()
+				u := Parse(id.UserAgent)
+				got := UserAgent{id.Browser, id.BrowserVersion, id.Engine, id.OS, id.OSVersion, id.DeviceModel, id.Type, id.Bot}
+				if id.Reduced { // the string carries frozen values instead
+					got.OSVersion, got.DeviceModel = u.OSVersion, u.DeviceModel
+				}
+				if got != u {
+					t.Fatalf("Identity %+v\nParse(%q) = %+v", got, id.UserAgent, u)
+				}
+				if id.DeviceBuild != "" && !id.Reduced && !strings.Contains(id.UserAgent, "Build/"+id.DeviceBuild+")") &&
+					!strings.Contains(id.UserAgent, "Build/"+id.DeviceBuild+";") {
+					t.Fatalf("DeviceBuild %q not in %q", id.DeviceBuild, id.UserAgent)
+				}
+			}
+		})
+	}
+}
+
+func TestRandomIdentityMatchesRandom(t *testing.T) {
+	a, b := WithSeed(7), WithSeed(7)
+	for i := 0; i < 1000; i++ {
+		if s, id := a.Random(), b.RandomIdentity(); s != id.UserAgent {
+			t.Fatalf("iteration %d: Random() = %q, RandomIdentity().UserAgent = %q", i, s, id.UserAgent)
+		}
+	}
+}
+
+func TestIdentityClientHints(t *testing.T) {
+	g := WithSeed(1)
+
+	id := g.ChromeIdentity()
+	h, ok := id.ClientHints()
+	if !ok {
+		t.Fatal("Chrome identity has no client hints")
+	}
+	if want := `"` + string(id.OS) + `"`; h.Platform != want {
+		t.Errorf("Platform = %s, want %s", h.Platform, want)
+	}
+
+	for _, id := range []Identity{g.FirefoxIdentity(), g.SafariIdentity(), g.ChromeIOSIdentity(), g.RandomBotIdentity()} {
+		if _, ok := id.ClientHints(); ok {
+			t.Errorf("%s %s on %s reports client hints", id.Browser, id.BrowserVersion, id.OS)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package ua
+
+import "time"
+
+// DatasetInfo describes the data a Generator samples from
+type DatasetInfo struct {
+	ID        string    // Dataset.ID, as in Identity.DatasetID
+	Source    string    // where the usage data came from
+	Generated time.Time // when it was extracted, zero if unknown
+
+	// Counts holds the number of entries in each table, keyed like the
+	// Dataset JSON: "chrome", "windows", "androidDevices", ...
+	Counts map[string]int
+
+	// Newest holds the newest version of each browser family
+	Newest map[Browser]string
+}
+
+// DataInfo describes the dataset behind the package-level functions:
+// the compiled-in data or the one set with SetDataset
+func DataInfo() DatasetInfo {
+	return active.Load().info()
+}
+
+// DataInfo describes the dataset g samples from
+func (g *Generator) DataInfo() DatasetInfo {
+	if g.pinned {
+		return g.t.info()
+	}
+	return active.Load().info()
+}
+
+// Age returns how long before now the data was generated, 0 if unknown
+func (i DatasetInfo) Age(now time.Time) time.Duration {
+	if i.Generated.IsZero() {
+		return 0
+	}
+	return now.Sub(i.Generated)
+}
+
+// Stale reports whether the data was generated more than maxAge before
+// now. Data without a generation time is always stale.
+//
+//	if ua.DataInfo().Stale(time.Now(), 90*24*time.Hour) { ... }
+func (i DatasetInfo) Stale(now time.Time, maxAge time.Duration) bool {
+	return i.Generated.IsZero() || i.Age(now) > maxAge
+}
+
+func (t *tables) info() DatasetInfo {
+	return DatasetInfo{
+		ID:        t.id,
+		Source:    t.source,
+		Generated: t.generated,
+		Counts: map[string]int{
+			"chrome":         len(t.chrome.values),
+			"firefox":        len(t.firefox.values),
+			"safari":         len(t.safari.values),
+			"edge":           len(t.edge.values),
+			"samsung":        len(t.samsung.values),
+			"windows":        len(t.windows.values),
+			"macos":          len(t.mac.values),
+			"linux":          len(t.linux.values),
+			"ios":            len(t.ios.values),
+			"android":        len(t.android.values),
+			"androidDevices": len(t.androidDevices.values),
+			"browserOS":      len(t.browserOS),
+			"deviceAndroid":  len(t.deviceAndroid),
+		},
+		Newest: map[Browser]string{
+			BrowserChrome:  newest(t.chrome.values),
+			BrowserFirefox: newest(t.firefox.values),
+			BrowserSafari:  newest(t.safari.values),
+			BrowserEdge:    newest(t.edge.values),
+			BrowserSamsung: newest(t.samsung.values),
+		},
+	}
+}
+
+// newest returns the highest of versions
+func newest(versions []string) string {
+	var v string
+	for _, candidate := range versions {
+		if v == "" || compareVersions(candidate, v) > 0 {
+			v = candidate
+		}
+	}
+	return v
+}
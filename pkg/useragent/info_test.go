@@ -0,0 +1,65 @@
+package ua
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDataInfo(t *testing.T) {
+	info := DataInfo()
+	if info.Source != dataSource || info.ID != DatasetID() {
+		t.Errorf("source %q, ID %q", info.Source, info.ID)
+	}
+	if want, _ := time.Parse(time.RFC3339, dataGenerated); !info.Generated.Equal(want) || want.IsZero() {
+		t.Errorf("generated %v, want %s", info.Generated, dataGenerated)
+	}
+	if info.Counts["chrome"] != len(chromeVersions.values) || info.Counts["androidDevices"] != len(androidDevices.values) {
+		t.Errorf("counts %v", info.Counts)
+	}
+	for _, b := range []Browser{BrowserChrome, BrowserFirefox, BrowserSafari, BrowserEdge, BrowserSamsung} {
+		v := info.Newest[b]
+		for _, other := range defaultTables.versions(b).values {
+			if compareVersions(other, v) > 0 {
+				t.Errorf("newest %s %s, but %s is newer", b, v, other)
+			}
+		}
+	}
+}
+
+func TestDataInfoFollowsDataset(t *testing.T) {
+	t.Cleanup(func() { SetDataset(nil) })
+
+	d := &Dataset{ID: "test", Source: "local", Chrome: []VersionWeight{{"99.0.0.0", 1}, {"150.0.0.0", 1}, {"100.0.0.0", 1}}}
+	g := New()
+	pinned := New(WithDataset(d))
+	if info := pinned.DataInfo(); info.ID != "test" || info.Newest[BrowserChrome] != "150.0.0.0" || info.Counts["chrome"] != 3 {
+		t.Errorf("pinned generator info %+v", info)
+	}
+
+	SetDataset(d)
+	if info := DataInfo(); info.ID != "test" || info.Source != "local" || !info.Generated.IsZero() {
+		t.Errorf("info after SetDataset %+v", info)
+	}
+	if info := g.DataInfo(); info.ID != "test" {
+		t.Errorf("existing generator reports dataset %q", info.ID)
+	}
+}
+
+func TestDatasetInfoStale(t *testing.T) {
+	generated := time.Date(2026, 1, 18, 12, 0, 0, 0, time.UTC)
+	info := DatasetInfo{Generated: generated}
+	now := generated.Add(30 * 24 * time.Hour)
+
+	if age := info.Age(now); age != 30*24*time.Hour {
+		t.Errorf("Age = %v", age)
+	}
+	if info.Stale(now, 60*24*time.Hour) {
+		t.Error("30 day old data stale with a 60 day limit")
+	}
+	if !info.Stale(now, 7*24*time.Hour) {
+		t.Error("30 day old data fresh with a 7 day limit")
+	}
+	if unknown := (DatasetInfo{}); unknown.Age(now) != 0 || !unknown.Stale(now, time.Hour) {
+		t.Error("data without a generation time not reported stale")
+	}
+}
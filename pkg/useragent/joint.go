@@ -0,0 +1,129 @@
+package ua
+
+import "fmt"
+
+// Joint tables record which values real traffic sends together, such as
+// the Windows releases each Chrome version is seen on, where the
+// independent tables only know how popular each value is on its own.
+// Generators pick OS releases and Android versions from them when the
+// data has a compatible entry, and from the independent tables otherwise.
+
+// BrowserOSWeight is how often a browser version is seen on an OS release
+type BrowserOSWeight struct {
+	Browser   string  `json:"browser"` // Dataset table: "chrome", "firefox", "safari", "edge" or "samsung"
+	Version   string  `json:"version"`
+	OS        string  `json:"os"`        // Dataset table: "windows", "macos", "ios" or "android"
+	OSVersion string  `json:"osVersion"` // in the format of that table
+	Weight    float64 `json:"weight"`
+}
+
+// DeviceAndroidWeight is how often an Android device model is seen on an
+// Android version
+type DeviceAndroidWeight struct {
+	Model   string  `json:"model"`
+	Android string  `json:"android"`
+	Weight  float64 `json:"weight"`
+}
+
+// Dataset table names of the browsers and OSes joint tables refer to
+var (
+	jointBrowsers = map[string]Browser{
+		"chrome":  BrowserChrome,
+		"firefox": BrowserFirefox,
+		"safari":  BrowserSafari,
+		"edge":    BrowserEdge,
+		"samsung": BrowserSamsung,
+	}
+	jointOSes = map[string]OS{
+		"windows": OSWindows,
+		"macos":   OSMacOS,
+		"ios":     OSIOS,
+		"android": OSAndroid,
+	}
+)
+
+// jointKey identifies the OS releases seen with one browser version
+type jointKey struct {
+	browser Browser
+	version string
+	os      OS
+}
+
+// validateJoint reports the first malformed entry of the joint tables of d
+func (d *Dataset) validateJoint() error {
+	for _, e := range d.BrowserOS {
+		_, knownBrowser := jointBrowsers[e.Browser]
+		_, knownOS := jointOSes[e.OS]
+		switch {
+		case !knownBrowser || !knownOS:
+			return fmt.Errorf("ua: dataset browserOS: unknown pair %s/%s", e.Browser, e.OS)
+		case e.Version == "" || e.OSVersion == "":
+			return fmt.Errorf("ua: dataset browserOS: %s %q on %s %q without a version", e.Browser, e.Version, e.OS, e.OSVersion)
+		case !validWeight(e.Weight):
+			return fmt.Errorf("ua: dataset browserOS: %s %s on %s %s has weight %g", e.Browser, e.Version, e.OS, e.OSVersion, e.Weight)
+		}
+	}
+	for _, e := range d.DeviceAndroid {
+		if e.Model == "" || e.Android == "" {
+			return fmt.Errorf("ua: dataset deviceAndroid: device %q on Android %q", e.Model, e.Android)
+		}
+		if !validWeight(e.Weight) {
+			return fmt.Errorf("ua: dataset deviceAndroid: %s on Android %s has weight %g", e.Model, e.Android, e.Weight)
+		}
+	}
+	return nil
+}
+
+// linkJoint builds the alias tables of the joint data held by t
+func (t *tables) linkJoint() {
+	byPair := make(map[jointKey][]entry[string])
+	for _, e := range t.browserOS {
+		b, knownBrowser := jointBrowsers[e.Browser]
+		os, knownOS := jointOSes[e.OS]
+		if knownBrowser && knownOS && e.Weight > 0 && validWeight(e.Weight) {
+			k := jointKey{b, e.Version, os}
+			byPair[k] = append(byPair[k], entry[string]{e.OSVersion, e.Weight})
+		}
+	}
+	t.osByBrowser = make(map[jointKey]weighted[string], len(byPair))
+	for k, entries := range byPair {
+		t.osByBrowser[k] = newWeighted(entries)
+	}
+
+	byModel := make(map[string][]entry[string])
+	for _, e := range t.deviceAndroid {
+		if e.Weight > 0 && validWeight(e.Weight) {
+			byModel[e.Model] = append(byModel[e.Model], entry[string]{e.Android, e.Weight})
+		}
+	}
+	t.androidByDevice = make(map[string]weighted[string], len(byModel))
+	for model, entries := range byModel {
+		t.androidByDevice[model] = newWeighted(entries)
+	}
+}
+
+// osReleases returns the releases of os seen with version of browser b
+// that keep accepts, falling back to those of the independent table
+func (t *tables) osReleases(b Browser, version string, os OS, independent weighted[string], keep func(string) bool) (weighted[string], bool) {
+	if joint, ok := t.osByBrowser[jointKey{b, version, os}]; ok {
+		if w, ok := joint.filter(keep); ok {
+			return w, true
+		}
+	}
+	return independent.filter(keep)
+}
+
+// androidReleases returns the Android versions each device model is seen
+// on that keep accepts
+func (t *tables) androidReleases(keep func(string) bool) map[string]weighted[string] {
+	if len(t.androidByDevice) == 0 {
+		return nil
+	}
+	m := make(map[string]weighted[string], len(t.androidByDevice))
+	for model, versions := range t.androidByDevice {
+		if w, ok := versions.filter(keep); ok {
+			m[model] = w
+		}
+	}
+	return m
+}
@@ -0,0 +1,87 @@
+package ua
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJointTables(t *testing.T) {
+	d := &Dataset{
+		Firefox: []VersionWeight{{"146.0", 1}, {"115.0", 1}},
+		Windows: []VersionWeight{{"10.0", 1}, {"6.1", 1}},
+		Android: []VersionWeight{{"16", 1}, {"12", 1}},
+		AndroidDevices: []DeviceWeight{
+			{"Pixel 10", "BP2A.250805.005", 1},
+			{"SM-A525F", "XYZ", 1}, // Android version unknown from the build
+		},
+		BrowserOS: []BrowserOSWeight{
+			{"firefox", "146.0", "windows", "10.0", 1},
+			{"firefox", "115.0", "windows", "6.1", 1},
+			{"chrome", "142.0.0.0", "windows", "5.1", 1}, // too old for Chrome 142
+		},
+		DeviceAndroid: []DeviceAndroidWeight{{"SM-A525F", "12", 1}},
+	}
+	if err := d.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	g := WithSeed(5, WithDataset(d))
+
+	seen := map[string]bool{}
+	for i := 0; i < 500; i++ {
+		id := g.FirefoxIdentity()
+		win, _ := tokenVersion(id.UserAgent, "Windows NT ")
+		win = strings.TrimSuffix(win, ";")
+		if want := map[string]string{"146.0": "10.0", "115.0": "6.1"}[id.BrowserVersion]; id.OS == OSWindows && win != want {
+			t.Fatalf("Firefox %s on Windows NT %s, want %s: %s", id.BrowserVersion, win, want, id.UserAgent)
+		}
+
+		if id := g.ChromeAndroidIdentity(); id.DeviceModel == "SM-A525F" && id.OSVersion != "12" {
+			t.Fatalf("%s on Android %s, want 12: %s", id.DeviceModel, id.OSVersion, id.UserAgent)
+		}
+
+		id = g.ChromeIdentity()
+		if id.OS == OSWindows {
+			seen[id.OSVersion] = true
+		}
+	}
+	if seen["5.1"] || len(seen) == 0 {
+		t.Errorf("Chrome on Windows %v, want the independent table", seen)
+	}
+}
+
+func TestJointDeviceAndroid(t *testing.T) {
+	d := &Dataset{
+		Android: []VersionWeight{{"15", 1}, {"14", 1}},
+		AndroidDevices: []DeviceWeight{
+			{"SM-S918B", "UP1A.231005.007", 1},
+			{"SM-S918B", "AP3A.240905.015.A2", 1},
+			{"Pixel 8", "AP2A.240805.005", 1},
+		},
+		DeviceAndroid: []DeviceAndroidWeight{{"SM-S918B", "15", 1}, {"Pixel 8", "14", 1}},
+	}
+	want := map[string]string{"SM-S918B": "AP3A.240905.015.A2", "Pixel 8": "UP1A.231005.007"}
+	g := WithSeed(5, WithDataset(d))
+	for i := 0; i < 200; i++ {
+		id := g.ChromeAndroidIdentity()
+		if id.DeviceBuild != want[id.DeviceModel] {
+			t.Fatalf("%s on Android %s with build %s, want %s", id.DeviceModel, id.OSVersion, id.DeviceBuild, want[id.DeviceModel])
+		}
+		if v, _ := androidBuildVersion(id.DeviceBuild); v != id.OSVersion {
+			t.Fatalf("%s build %s on Android %s", id.DeviceModel, id.DeviceBuild, id.OSVersion)
+		}
+	}
+}
+
+func TestReadDatasetJointInvalid(t *testing.T) {
+	tests := []string{
+		`{"browserOS": [{"browser": "opera", "version": "1", "os": "windows", "osVersion": "10.0", "weight": 1}]}`,
+		`{"browserOS": [{"browser": "chrome", "version": "142.0.0.0", "os": "windows", "weight": 1}]}`,
+		`{"browserOS": [{"browser": "chrome", "version": "142.0.0.0", "os": "windows", "osVersion": "10.0", "weight": -1}]}`,
+		`{"deviceAndroid": [{"model": "Pixel 8", "weight": 1}]}`,
+	}
+	for _, in := range tests {
+		if _, err := ReadDataset(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadDataset(%s) succeeded", in)
+		}
+	}
+}
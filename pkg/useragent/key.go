@@ -0,0 +1,50 @@
+package ua
+
+// ForKey returns the Identity of key, such as an account or proxy name.
+// Generators with the same seed, options and data always give a key the
+// same Identity, whatever they generated before and in whichever order
+// keys are asked for, so nothing needs to be stored to keep them. It is
+// a desktop or mobile browser, never a bot, drawn in the proportions
+// WithMix sets, and does not advance g.
+//
+//	g := ua.WithSeed(1, ua.MustSnapshot("2026-01-18"))
+//	g.ForKey("proxy-7") // the same Identity in every run and release
+func (g *Generator) ForKey(key string) Identity {
+	sub := *g
+	sub.rng = newXorshift64(keySeed(g.seed, key))
+	return sub.browserIdentity()
+}
+
+// browserIdentity generates a desktop or mobile identity, like
+// RandomIdentity without bots
+func (g *Generator) browserIdentity() Identity {
+	var category UAType
+	if browsers, ok := g.browserCategories(); ok {
+		category = browsers.pick(g.rng)
+	} else {
+		category = UAType(g.rng.intn(2)) // TypeDesktop or TypeMobile
+	}
+	if category == TypeMobile {
+		return g.RandomMobileIdentity()
+	}
+	return g.RandomDesktopIdentity()
+}
+
+// browserCategories returns the WithMix categories without bots
+func (g *Generator) browserCategories() (weighted[UAType], bool) {
+	if g.mix == nil {
+		return weighted[UAType]{}, false
+	}
+	return g.mix.category.filter(func(t UAType) bool { return t != TypeBot })
+}
+
+// keySeed hashes key into the namespace of seed: FNV-1a, finished with
+// mix64 so similar keys start far apart
+func keySeed(seed uint64, key string) uint64 {
+	h := uint64(14695981039346656037) ^ seed
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+	return mix64(h)
+}
@@ -0,0 +1,79 @@
+package ua
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestForKey(t *testing.T) {
+	a, b := WithSeed(1), WithSeed(1)
+	b.Random() // a different history must not matter
+	b.Chrome()
+
+	distinct := map[string]bool{}
+	for i := 99; i >= 0; i-- {
+		key := fmt.Sprintf("account-%d", i)
+		x := a.ForKey(key)
+		if y := b.ForKey(key); x != y {
+			t.Fatalf("ForKey(%s) = %q and %q", key, x.UserAgent, y.UserAgent)
+		}
+		distinct[x.UserAgent] = true
+	}
+	if len(distinct) < 50 {
+		t.Errorf("100 keys share %d User-Agents", len(distinct))
+	}
+
+	if a.ForKey("account-1") == WithSeed(2).ForKey("account-1") {
+		t.Error("seeds 1 and 2 give account-1 the same identity")
+	}
+	if a.Random() != WithSeed(1).Random() {
+		t.Error("ForKey advanced the generator")
+	}
+}
+
+func TestForKeyBrowsersOnly(t *testing.T) {
+	tests := []struct {
+		g      *Generator
+		mobile bool // only mobile identities
+	}{
+		{WithSeed(1), false},
+		{WithSeed(1, WithMix(Mix{Bot: 1})), false},
+		{WithSeed(1, WithMix(Mix{Mobile: 1, Bot: 1})), true},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 300; i++ {
+			id := tt.g.ForKey(fmt.Sprintf("account-%d", i))
+			if id.Bot != "" {
+				t.Fatalf("account-%d is the bot %s", i, id.Bot)
+			}
+			if tt.mobile && id.Type != TypeMobile && id.Type != TypeTablet {
+				t.Fatalf("account-%d is %v with a mobile-only mix", i, id.Type)
+			}
+		}
+	}
+}
+
+func TestForKeyConcurrent(t *testing.T) {
+	g := WithSeed(1, MustSnapshot("2026-01-18"))
+	want := g.ForKey("proxy-7")
+	// Pinned: a snapshot keeps every key's identity across releases
+	if ua := "Mozilla/5.0 (iPhone; CPU iPhone OS 18_7 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.7.2 Mobile/15E148 Safari/605.1.15"; want.UserAgent != ua {
+		t.Errorf("ForKey(proxy-7) = %q, want %q", want.UserAgent, ua)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if id := g.ForKey("proxy-7"); id != want {
+					t.Errorf("ForKey(proxy-7) = %q, want %q", id.UserAgent, want.UserAgent)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,111 @@
+package ua
+
+// Mix weights the choices made by Random, RandomDesktop and RandomMobile.
+// Weights are relative, so only their ratios matter, and a zero weight
+// excludes a choice. A level without any positive weight keeps its
+// default uniform choice.
+type Mix struct {
+	// Categories picked by Random
+	Desktop, Mobile, Bot float64
+
+	// Browser families picked by RandomDesktop (Chrome, Firefox, Safari,
+	// Edge) and RandomMobile (Safari, Chrome, Android WebView, Firefox,
+	// Samsung Internet, Edge). Chrome's mobile weight is shared by Android
+	// and iOS. Other browsers are ignored.
+	DesktopBrowsers map[Browser]float64
+	MobileBrowsers  map[Browser]float64
+}
+
+// WithMix sets the category and browser family weights.
+// For example, 60% desktop Chrome, 40% mobile Safari and no bots:
+//
+//	ua.New(ua.WithMix(ua.Mix{
+//		Desktop:         0.6,
+//		Mobile:          0.4,
+//		DesktopBrowsers: map[ua.Browser]float64{ua.BrowserChrome: 1},
+//		MobileBrowsers:  map[ua.Browser]float64{ua.BrowserSafari: 1},
+//	}))
+func WithMix(m Mix) Option {
+	mx := m.compile()
+	return func(g *Generator) {
+		g.mix = mx
+	}
+}
+
+// mix is a Mix compiled into alias tables
+type mix struct {
+	category weighted[UAType]
+	desktop  weighted[func(*Generator) Identity]
+	mobile   weighted[func(*Generator) Identity]
+}
+
+// family is a browser generator picked by RandomDesktop or RandomMobile
+type family struct {
+	browser Browser
+	gen     func(*Generator) Identity
+	uniform bool // part of the default uniform choice
+}
+
+var desktopFamilies = []family{
+	{BrowserChrome, (*Generator).chrome, true},
+	{BrowserFirefox, (*Generator).firefox, true},
+	{BrowserSafari, (*Generator).safari, true},
+	{BrowserEdge, (*Generator).edge, true},
+}
+
+var mobileFamilies = []family{
+	{BrowserSafari, (*Generator).safariIOS, true},
+	{BrowserChrome, (*Generator).chromeAndroid, true},
+	{BrowserChrome, (*Generator).chromeIOS, true},
+	{BrowserWebView, (*Generator).androidWebView, true},
+	{BrowserFirefox, (*Generator).firefoxAndroid, false},
+	{BrowserSamsung, (*Generator).samsungBrowser, false},
+	{BrowserEdge, (*Generator).edgeAndroid, false},
+}
+
+func (m Mix) compile() *mix {
+	categories := []entry[UAType]{{TypeDesktop, m.Desktop}, {TypeMobile, m.Mobile}, {TypeBot, m.Bot}}
+	if !anyPositive(categories) {
+		categories = []entry[UAType]{{TypeDesktop, 1}, {TypeMobile, 1}, {TypeBot, 1}}
+	}
+	return &mix{
+		category: newWeighted(categories),
+		desktop:  newWeighted(familyEntries(desktopFamilies, m.DesktopBrowsers)),
+		mobile:   newWeighted(familyEntries(mobileFamilies, m.MobileBrowsers)),
+	}
+}
+
+// familyEntries weights families by browser, splitting a browser's weight
+// evenly among its families. Without any positive weight the default
+// uniform families are returned.
+func familyEntries(families []family, weights map[Browser]float64) []entry[func(*Generator) Identity] {
+	count := make(map[Browser]int, len(families))
+	for _, f := range families {
+		count[f.browser]++
+	}
+
+	entries := make([]entry[func(*Generator) Identity], 0, len(families))
+	for _, f := range families {
+		entries = append(entries, entry[func(*Generator) Identity]{f.gen, weights[f.browser] / float64(count[f.browser])})
+	}
+	if anyPositive(entries) {
+		return entries
+	}
+
+	entries = entries[:0]
+	for _, f := range families {
+		if f.uniform {
+			entries = append(entries, entry[func(*Generator) Identity]{f.gen, 1})
+		}
+	}
+	return entries
+}
+
+func anyPositive[T any](entries []entry[T]) bool {
+	for _, e := range entries {
+		if e.weight > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package ua
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMixCategories(t *testing.T) {
+	g := WithSeed(42, WithMix(Mix{Desktop: 0.6, Mobile: 0.4}))
+
+	const n = 20000
+	counts := make(map[UAType]int)
+	for i := 0; i < n; i++ {
+		counts[g.RandomIdentity().Type]++
+	}
+
+	if counts[TypeBot] != 0 {
+		t.Errorf("got %d bots with zero bot weight", counts[TypeBot])
+	}
+	if share := float64(counts[TypeDesktop]) / n; math.Abs(share-0.6) > 0.02 {
+		t.Errorf("desktop share = %.3f, want 0.6", share)
+	}
+}
+
+func TestMixBrowsers(t *testing.T) {
+	g := WithSeed(42, WithMix(Mix{
+		DesktopBrowsers: map[Browser]float64{BrowserChrome: 3, BrowserFirefox: 1},
+		MobileBrowsers:  map[Browser]float64{BrowserSamsung: 1, BrowserChrome: 1},
+	}))
+
+	const n = 20000
+	desktop := make(map[Browser]int)
+	mobile := make(map[OS]int)
+	for i := 0; i < n; i++ {
+		desktop[g.RandomDesktopIdentity().Browser]++
+
+		id := g.RandomMobileIdentity()
+		if id.Browser != BrowserSamsung && id.Browser != BrowserChrome {
+			t.Fatalf("unexpected mobile browser %q", id.Browser)
+		}
+		if id.Browser == BrowserChrome {
+			mobile[id.OS]++
+		}
+	}
+
+	if len(desktop) != 2 {
+		t.Errorf("desktop browsers = %v, want Chrome and Firefox only", desktop)
+	}
+	if share := float64(desktop[BrowserChrome]) / n; math.Abs(share-0.75) > 0.02 {
+		t.Errorf("Chrome desktop share = %.3f, want 0.75", share)
+	}
+	if mobile[OSAndroid] == 0 || mobile[OSIOS] == 0 {
+		t.Errorf("mobile Chrome = %v, want both Android and iOS", mobile)
+	}
+}
+
+func TestMixDefaults(t *testing.T) {
+	// Levels without positive weights keep the uniform default
+	g := WithSeed(7, WithMix(Mix{Bot: 1, MobileBrowsers: map[Browser]float64{BrowserOpera: 1}}))
+	for i := 0; i < 100; i++ {
+		if id := g.RandomIdentity(); id.Type != TypeBot {
+			t.Fatalf("Random() = %q, want only bots", id.UserAgent)
+		}
+	}
+
+	seen := make(map[Browser]bool)
+	for i := 0; i < 1000; i++ {
+		seen[g.RandomMobileIdentity().Browser] = true
+	}
+	if len(seen) != 3 || !seen[BrowserSafari] || !seen[BrowserChrome] || !seen[BrowserWebView] {
+		t.Errorf("mobile browsers = %v, want the default Safari, Chrome and WebView", seen)
+	}
+
+	if c := g.Clone(); c.mix != g.mix {
+		t.Error("Clone dropped the mix")
+	}
+}
@@ -0,0 +1,276 @@
+package ua
+
+import (
+	"regexp"
+	"strings"
+)
+
+// UserAgent is the structured form of a User-Agent string returned by Parse.
+// Fields the string does not reveal are left empty.
+type UserAgent struct {
+	Browser        Browser
+	BrowserVersion string // as sent, e.g. "142.0.0.0", "146.0", "18.6"
+	Engine         Engine
+	OS             OS
+	OSVersion      string // dotted, e.g. "10.0" (Windows NT), "10.15.7", "17.4.1", "14"
+	DeviceModel    string // e.g. "SM-S911B", "iPhone", "iPad"
+	Type           UAType // device class
+	Bot            string // bot name for crawlers, e.g. "Googlebot"
+}
+
+// Parse extracts browser, engine, OS and device information from a
+// User-Agent string. It understands every format this package generates
+// and the common real-world ones; unknown parts are left empty.
+func Parse(ua string) UserAgent {
+	var u UserAgent
+	platform := platformToken(ua)
+
+	parseOS(&u, ua, platform)
+	parseBrowser(&u, ua)
+
+	if name := botName(ua); name != "" {
+		u.Bot = name
+		u.Type = TypeBot
+	}
+
+	return u
+}
+
+// platformToken returns the contents of the first parenthesized comment,
+// e.g. "Windows NT 10.0; Win64; x64"
+func platformToken(ua string) string {
+	start := strings.IndexByte(ua, '(')
+	if start < 0 {
+		return ""
+	}
+	end := strings.IndexByte(ua[start:], ')')
+	if end < 0 {
+		return ua[start+1:]
+	}
+	return ua[start+1 : start+end]
+}
+
+// tokenVersion returns the version following prefix (e.g. "Chrome/"),
+// up to the next space, semicolon or parenthesis
+func tokenVersion(ua, prefix string) (string, bool) {
+	i := strings.Index(ua, prefix)
+	if i < 0 {
+		return "", false
+	}
+	v := ua[i+len(prefix):]
+	if end := strings.IndexAny(v, " ;)"); end >= 0 {
+		v = v[:end]
+	}
+	return v, true
+}
+
+func parseOS(u *UserAgent, ua, platform string) {
+	u.Type = TypeDesktop
+
+	switch {
+	case strings.Contains(platform, "iPhone"), strings.Contains(platform, "iPod"):
+		u.OS = OSIOS
+		u.OSVersion = iosVersion(platform)
+		u.DeviceModel = "iPhone"
+		if strings.Contains(platform, "iPod") {
+			u.DeviceModel = "iPod"
+		}
+		u.Type = TypeMobile
+	case strings.Contains(platform, "iPad"):
+		u.OS = OSIOS
+		u.OSVersion = iosVersion(platform)
+		u.DeviceModel = "iPad"
+		u.Type = TypeTablet
+	case strings.Contains(platform, "Android"):
+		parseAndroid(u, platform)
+		if !strings.Contains(ua, "Mobile") { // Android tablets drop the Mobile token
+			u.Type = TypeTablet
+		}
+	case strings.Contains(platform, "Windows NT "):
+		u.OS = OSWindows
+		u.OSVersion, _ = tokenVersion(platform, "Windows NT ")
+	case strings.Contains(platform, "CrOS"):
+		u.OS = OSChromeOS
+		if f := strings.Fields(platform[strings.Index(platform, "CrOS"):]); len(f) >= 3 {
+			u.OSVersion = f[2] // CrOS x86_64 14541.0.0
+		}
+	case strings.Contains(platform, "Mac OS X"):
+		u.OS = OSMacOS
+		if v, ok := tokenVersion(platform, "Mac OS X "); ok {
+			u.OSVersion = strings.ReplaceAll(v, "_", ".")
+		}
+	case strings.Contains(platform, "Linux"), strings.Contains(platform, "X11"):
+		u.OS = OSLinux
+	}
+}
+
+// iosVersion extracts "17.4.1" from "iPhone; CPU iPhone OS 17_4_1 like Mac OS X"
+func iosVersion(platform string) string {
+	v, ok := tokenVersion(platform, " OS ")
+	if !ok {
+		return ""
+	}
+	return strings.ReplaceAll(v, "_", ".")
+}
+
+// parseAndroid handles both the Chromium "Linux; Android 14; SM-S911B Build/..."
+// and the Firefox "Android 14; Mobile; rv:133.0" platform layouts
+func parseAndroid(u *UserAgent, platform string) {
+	u.OS = OSAndroid
+	u.Type = TypeMobile
+
+	parts := strings.Split(platform, ";")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	model := -1
+	for i, p := range parts {
+		if v, ok := strings.CutPrefix(p, "Android"); ok {
+			u.OSVersion = strings.TrimSpace(v)
+			model = i + 1
+			break
+		}
+	}
+
+	if model < 0 {
+		return
+	}
+	for _, p := range parts[model:] {
+		switch {
+		case p == "Mobile", p == "Tablet", p == "wv", p == "U", strings.HasPrefix(p, "rv:"), isLocale(p):
+		default:
+			u.DeviceModel, _, _ = strings.Cut(p, " Build/")
+			return
+		}
+	}
+}
+
+// isLocale reports whether s is a lowercase locale tag such as "en" or "en-us"
+func isLocale(s string) bool {
+	if len(s) != 2 && (len(s) != 5 || s[2] != '-') {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' && (s[i] < 'a' || s[i] > 'z') {
+			return false
+		}
+	}
+	return s != "wv"
+}
+
+func parseBrowser(u *UserAgent, ua string) {
+	var ok bool
+
+	// Order matters: most derived browsers also carry Chrome/ and Safari/ tokens
+	switch {
+	case strings.Contains(ua, "EdgiOS/"):
+		u.Browser = BrowserEdge
+		u.BrowserVersion, _ = tokenVersion(ua, "EdgiOS/")
+		u.Engine = EngineWebKit
+	case strings.Contains(ua, "Edg/"), strings.Contains(ua, "EdgA/"):
+		u.Browser = BrowserEdge
+		if u.BrowserVersion, ok = tokenVersion(ua, "Edg/"); !ok {
+			u.BrowserVersion, _ = tokenVersion(ua, "EdgA/")
+		}
+		u.Engine = EngineBlink
+	case strings.Contains(ua, "Edge/"):
+		u.Browser = BrowserEdge
+		u.BrowserVersion, _ = tokenVersion(ua, "Edge/")
+		u.Engine = EngineEdgeHTML
+	case strings.Contains(ua, "SamsungBrowser/"):
+		u.Browser = BrowserSamsung
+		u.BrowserVersion, _ = tokenVersion(ua, "SamsungBrowser/")
+		u.Engine = EngineBlink
+	case strings.Contains(ua, "OPR/"):
+		u.Browser = BrowserOpera
+		u.BrowserVersion, _ = tokenVersion(ua, "OPR/")
+		u.Engine = EngineBlink
+	case strings.Contains(ua, "YaBrowser/"):
+		u.Browser = BrowserYandex
+		u.BrowserVersion, _ = tokenVersion(ua, "YaBrowser/")
+		u.Engine = EngineBlink
+	case strings.Contains(ua, "CriOS/"):
+		u.Browser = BrowserChrome
+		u.BrowserVersion, _ = tokenVersion(ua, "CriOS/")
+		u.Engine = EngineWebKit
+	case strings.Contains(ua, "FxiOS/"):
+		u.Browser = BrowserFirefox
+		u.BrowserVersion, _ = tokenVersion(ua, "FxiOS/")
+		u.Engine = EngineWebKit
+	case strings.Contains(ua, "; wv)") && strings.Contains(ua, "Chrome/"):
+		u.Browser = BrowserWebView
+		u.BrowserVersion, _ = tokenVersion(ua, "Chrome/")
+		u.Engine = EngineBlink
+	case strings.Contains(ua, "Firefox/"):
+		u.Browser = BrowserFirefox
+		u.BrowserVersion, _ = tokenVersion(ua, "Firefox/")
+		u.Engine = EngineGecko
+	case strings.Contains(ua, "Chrome/"):
+		u.Browser = BrowserChrome
+		u.BrowserVersion, _ = tokenVersion(ua, "Chrome/")
+		u.Engine = EngineBlink
+	case strings.Contains(ua, "Version/") && strings.Contains(ua, "Safari/"):
+		u.Browser = BrowserSafari
+		u.BrowserVersion, _ = tokenVersion(ua, "Version/")
+		u.Engine = EngineWebKit
+	case strings.Contains(ua, "Trident/"), strings.Contains(ua, "MSIE "):
+		u.Browser = BrowserInternetExplorer
+		if u.BrowserVersion, ok = tokenVersion(ua, "MSIE "); !ok {
+			u.BrowserVersion, _ = tokenVersion(ua, "rv:")
+		}
+		u.Engine = EngineTrident
+	case strings.Contains(ua, "AppleWebKit/"):
+		u.Engine = EngineWebKit
+	case strings.Contains(ua, "Gecko/"):
+		u.Engine = EngineGecko
+	}
+}
+
+// knownBots maps a distinctive User-Agent substring to the bot name.
+// Names match the functions that generate them.
+var knownBots = []struct {
+	token string
+	name  string
+}{
+	{"Googlebot", "Googlebot"},
+	{"bingbot", "Bingbot"},
+	{"YandexBot", "YandexBot"},
+	{"Baiduspider", "Baiduspider"},
+	{"DuckDuckBot", "DuckDuckBot"},
+	{"facebookexternalhit", "FacebookBot"},
+	{"Twitterbot", "TwitterBot"},
+	{"LinkedInBot", "LinkedInBot"},
+	{"Slackbot", "SlackBot"},
+	{"TelegramBot", "TelegramBot"},
+	{"Discordbot", "DiscordBot"},
+	{"WhatsApp/", "WhatsAppBot"},
+	{"Pinterest/", "PinterestBot"},
+	{"AhrefsBot", "AhrefsBot"},
+	{"SemrushBot", "SemrushBot"},
+	{"DotBot", "MozBot"},
+	{"MJ12bot", "MajesticBot"},
+	{"Screaming Frog SEO Spider", "ScreamingFrogBot"},
+	{"SitebulbBot", "SitebulbBot"},
+}
+
+// genericBotRe matches product tokens of crawlers we do not know by name
+var genericBotRe = regexp.MustCompile(`(?i)\b([a-z][\w.-]*(?:bot|spider|crawler))\b`)
+
+// botName returns the crawler name for bot User-Agents, or ""
+func botName(ua string) string {
+	for _, b := range knownBots {
+		if strings.Contains(ua, b.token) {
+			return b.name
+		}
+	}
+	// Crawlers identify themselves with a URL or without the Mozilla prefix;
+	// the check keeps device models like "Cubot" from matching.
+	if !strings.Contains(ua, "http") && strings.HasPrefix(ua, "Mozilla/") {
+		return ""
+	}
+	if m := genericBotRe.FindStringSubmatch(ua); m != nil {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,197 @@
+package ua
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRealWorld(t *testing.T) {
+	tests := []struct {
+		ua   string
+		want UserAgent
+	}{
+		{
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36",
+			UserAgent{Browser: BrowserChrome, BrowserVersion: "131.0.0.0", Engine: EngineBlink, OS: OSWindows, OSVersion: "10.0", Type: TypeDesktop},
+		},
+		{
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:133.0) Gecko/20100101 Firefox/133.0",
+			UserAgent{Browser: BrowserFirefox, BrowserVersion: "133.0", Engine: EngineGecko, OS: OSMacOS, OSVersion: "10.15", Type: TypeDesktop},
+		},
+		{
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Safari/605.1.15",
+			UserAgent{Browser: BrowserSafari, BrowserVersion: "17.4.1", Engine: EngineWebKit, OS: OSMacOS, OSVersion: "10.15.7", Type: TypeDesktop},
+		},
+		{
+			"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36 Edg/131.0.2903.51",
+			UserAgent{Browser: BrowserEdge, BrowserVersion: "131.0.2903.51", Engine: EngineBlink, OS: OSLinux, Type: TypeDesktop},
+		},
+		{
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/70.0.3538.102 Safari/537.36 Edge/18.19045",
+			UserAgent{Browser: BrowserEdge, BrowserVersion: "18.19045", Engine: EngineEdgeHTML, OS: OSWindows, OSVersion: "10.0", Type: TypeDesktop},
+		},
+		{
+			"Mozilla/5.0 (Windows NT 6.1; WOW64; Trident/7.0; rv:11.0) like Gecko",
+			UserAgent{Browser: BrowserInternetExplorer, BrowserVersion: "11.0", Engine: EngineTrident, OS: OSWindows, OSVersion: "6.1", Type: TypeDesktop},
+		},
+		{
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/130.0.0.0 Safari/537.36 OPR/115.0.0.0",
+			UserAgent{Browser: BrowserOpera, BrowserVersion: "115.0.0.0", Engine: EngineBlink, OS: OSWindows, OSVersion: "10.0", Type: TypeDesktop},
+		},
+		{
+			"Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36",
+			UserAgent{Browser: BrowserChrome, BrowserVersion: "131.0.0.0", Engine: EngineBlink, OS: OSChromeOS, OSVersion: "14541.0.0", Type: TypeDesktop},
+		},
+		{
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+			UserAgent{Browser: BrowserSafari, BrowserVersion: "17.4", Engine: EngineWebKit, OS: OSIOS, OSVersion: "17.4.1", DeviceModel: "iPhone", Type: TypeMobile},
+		},
+		{
+			"Mozilla/5.0 (iPad; CPU OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/131.0.6778.73 Mobile/15E148 Safari/604.1",
+			UserAgent{Browser: BrowserChrome, BrowserVersion: "131.0.6778.73", Engine: EngineWebKit, OS: OSIOS, OSVersion: "17.4", DeviceModel: "iPad", Type: TypeTablet},
+		},
+		{
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) FxiOS/133.0 Mobile/15E148 Safari/605.1.15",
+			UserAgent{Browser: BrowserFirefox, BrowserVersion: "133.0", Engine: EngineWebKit, OS: OSIOS, OSVersion: "17.4", DeviceModel: "iPhone", Type: TypeMobile},
+		},
+		{
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 18_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.0 EdgiOS/142.0.3595.66 Mobile/15E148 Safari/605.1.15",
+			UserAgent{Browser: BrowserEdge, BrowserVersion: "142.0.3595.66", Engine: EngineWebKit, OS: OSIOS, OSVersion: "18.6", DeviceModel: "iPhone", Type: TypeMobile},
+		},
+		{
+			"Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Mobile Safari/537.36",
+			UserAgent{Browser: BrowserChrome, BrowserVersion: "131.0.0.0", Engine: EngineBlink, OS: OSAndroid, OSVersion: "10", DeviceModel: "K", Type: TypeMobile},
+		},
+		{
+			"Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/131.0.0.0 Safari/537.36",
+			UserAgent{Browser: BrowserChrome, BrowserVersion: "131.0.0.0", Engine: EngineBlink, OS: OSAndroid, OSVersion: "13", DeviceModel: "SM-X700", Type: TypeTablet},
+		},
+		{
+			"Mozilla/5.0 (Linux; U; Android 4.0.3; ko-kr; LG-L160L Build/IML74K) AppleWebkit/534.30 (KHTML, like Gecko) Version/4.0 Mobile Safari/534.30",
+			UserAgent{Browser: BrowserSafari, BrowserVersion: "4.0", Engine: EngineWebKit, OS: OSAndroid, OSVersion: "4.0.3", DeviceModel: "LG-L160L", Type: TypeMobile},
+		},
+		{
+			"Mozilla/5.0 (Android 14; Mobile; rv:133.0) Gecko/133.0 Firefox/133.0",
+			UserAgent{Browser: BrowserFirefox, BrowserVersion: "133.0", Engine: EngineGecko, OS: OSAndroid, OSVersion: "14", Type: TypeMobile},
+		},
+		{
+			"Mozilla/5.0 (Linux; Android 14; SM-S911B Build/UP1A.231005.007; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/131.0.6778.39 Mobile Safari/537.36",
+			UserAgent{Browser: BrowserWebView, BrowserVersion: "131.0.6778.39", Engine: EngineBlink, OS: OSAndroid, OSVersion: "14", DeviceModel: "SM-S911B", Type: TypeMobile},
+		},
+		{
+			"Mozilla/5.0 (Linux; Android 14; SM-S928B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/27.0 Chrome/125.0.0.0 Mobile Safari/537.36",
+			UserAgent{Browser: BrowserSamsung, BrowserVersion: "27.0", Engine: EngineBlink, OS: OSAndroid, OSVersion: "14", DeviceModel: "SM-S928B", Type: TypeMobile},
+		},
+		{
+			googlebotMobileUA,
+			UserAgent{Browser: BrowserChrome, BrowserVersion: "131.0.6778.85", Engine: EngineBlink, OS: OSAndroid, OSVersion: "6.0.1", DeviceModel: "Nexus 5X", Type: TypeBot, Bot: "Googlebot"},
+		},
+		{
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_5) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/13.1.1 Safari/605.1.15 (Applebot/0.1; +http://www.apple.com/go/applebot)",
+			UserAgent{Browser: BrowserSafari, BrowserVersion: "13.1.1", Engine: EngineWebKit, OS: OSMacOS, OSVersion: "10.15.5", Type: TypeBot, Bot: "Applebot"},
+		},
+		{
+			"Mozilla/5.0 (Linux; Android 12; Cubot X30) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+			UserAgent{Browser: BrowserChrome, BrowserVersion: "120.0.0.0", Engine: EngineBlink, OS: OSAndroid, OSVersion: "12", DeviceModel: "Cubot X30", Type: TypeMobile},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ua, func(t *testing.T) {
+			if got := Parse(tt.ua); got != tt.want {
+				t.Errorf("Parse() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBots(t *testing.T) {
+	bots := map[string]func() string{
+		"Googlebot":        Googlebot,
+		"Bingbot":          BingbotMobile,
+		"YandexBot":        YandexBot,
+		"Baiduspider":      Baiduspider,
+		"DuckDuckBot":      DuckDuckBot,
+		"FacebookBot":      FacebookBot,
+		"TwitterBot":       TwitterBot,
+		"LinkedInBot":      LinkedInBot,
+		"SlackBot":         SlackBot,
+		"TelegramBot":      TelegramBot,
+		"DiscordBot":       DiscordBot,
+		"WhatsAppBot":      WhatsAppBot,
+		"PinterestBot":     PinterestBot,
+		"AhrefsBot":        AhrefsBot,
+		"SemrushBot":       SemrushBot,
+		"MozBot":           MozBot,
+		"MajesticBot":      MajesticBot,
+		"ScreamingFrogBot": ScreamingFrogBot,
+		"SitebulbBot":      SitebulbBot,
+	}
+
+	for name, fn := range bots {
+		t.Run(name, func(t *testing.T) {
+			u := Parse(fn())
+			if u.Bot != name || u.Type != TypeBot {
+				t.Errorf("Parse(%q) = bot %q type %v, want %q bot", fn(), u.Bot, u.Type, name)
+			}
+		})
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	g := WithSeed(42)
+
+	generators := []struct {
+		name    string
+		fn      func() string
+		browser Browser
+		engine  Engine
+		os      OS
+		typ     UAType
+		token   string // prefix of the browser version in the string
+	}{
+		{"Chrome", g.Chrome, BrowserChrome, EngineBlink, "", TypeDesktop, "Chrome/"},
+		{"ChromeWindows", g.ChromeWindows, BrowserChrome, EngineBlink, OSWindows, TypeDesktop, "Chrome/"},
+		{"ChromeMac", g.ChromeMac, BrowserChrome, EngineBlink, OSMacOS, TypeDesktop, "Chrome/"},
+		{"ChromeLinux", g.ChromeLinux, BrowserChrome, EngineBlink, OSLinux, TypeDesktop, "Chrome/"},
+		{"Firefox", g.Firefox, BrowserFirefox, EngineGecko, "", TypeDesktop, "Firefox/"},
+		{"FirefoxWindows", g.FirefoxWindows, BrowserFirefox, EngineGecko, OSWindows, TypeDesktop, "Firefox/"},
+		{"FirefoxMac", g.FirefoxMac, BrowserFirefox, EngineGecko, OSMacOS, TypeDesktop, "Firefox/"},
+		{"Safari", g.Safari, BrowserSafari, EngineWebKit, OSMacOS, TypeDesktop, "Version/"},
+		{"Edge", g.Edge, BrowserEdge, EngineBlink, "", TypeDesktop, "Edg/"},
+		{"EdgeWindows", g.EdgeWindows, BrowserEdge, EngineBlink, OSWindows, TypeDesktop, "Edg/"},
+		{"SafariIOS", g.SafariIOS, BrowserSafari, EngineWebKit, OSIOS, TypeMobile, "Version/"},
+		{"SafariIPad", g.SafariIPad, BrowserSafari, EngineWebKit, OSIOS, TypeTablet, "Version/"},
+		{"ChromeIOS", g.ChromeIOS, BrowserChrome, EngineWebKit, OSIOS, TypeMobile, "CriOS/"},
+		{"ChromeAndroid", g.ChromeAndroid, BrowserChrome, EngineBlink, OSAndroid, TypeMobile, "Chrome/"},
+		{"AndroidWebView", g.AndroidWebView, BrowserWebView, EngineBlink, OSAndroid, TypeMobile, "Chrome/"},
+		{"FirefoxAndroid", g.FirefoxAndroid, BrowserFirefox, EngineGecko, OSAndroid, TypeMobile, "Firefox/"},
+		{"SamsungBrowser", g.SamsungBrowser, BrowserSamsung, EngineBlink, OSAndroid, TypeMobile, "SamsungBrowser/"},
+		{"EdgeAndroid", g.EdgeAndroid, BrowserEdge, EngineBlink, OSAndroid, TypeMobile, "EdgA/"},
+	}
+
+	for _, gen := range generators {
+		t.Run(gen.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				s := gen.fn()
+				u := Parse(s)
+
+				if u.Browser != gen.browser || u.Engine != gen.engine || u.Type != gen.typ {
+					t.Fatalf("Parse(%q) = %+v", s, u)
+				}
+				if gen.os != "" && u.OS != gen.os {
+					t.Fatalf("Parse(%q).OS = %q, want %q", s, u.OS, gen.os)
+				}
+				if u.OS == "" {
+					t.Fatalf("Parse(%q) found no OS", s)
+				}
+				if !strings.Contains(s, gen.token+u.BrowserVersion) || u.BrowserVersion == "" {
+					t.Fatalf("Parse(%q).BrowserVersion = %q", s, u.BrowserVersion)
+				}
+				if u.OS == OSAndroid && u.OSVersion == "" {
+					t.Fatalf("Parse(%q) found no Android version", s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package ua
+
+import "strings"
+
+// Platform details that never appear in the User-Agent string but are
+// exposed through high-entropy client hints. Not covered by the usage
+// data, so maintained by hand.
+
+// Sec-CH-UA-Platform-Version values reported for Windows NT 10.0.
+// Windows 10 reports 1.0.0-10.0.0, Windows 11 reports 13.0.0 and above.
+var windowsNT10PlatformVersions = newWeighted([]entry[string]{
+	{"10.0.0", 0.45}, // Windows 10 2004-22H2
+	{"19.0.0", 0.25}, // Windows 11 24H2
+	{"15.0.0", 0.22}, // Windows 11 22H2/23H2
+	{"14.0.0", 0.03}, // Windows 11 21H2
+	{"8.0.0", 0.03},  // Windows 10 1903/1909
+	{"7.0.0", 0.02},  // Windows 10 1809
+})
+
+// Linux kernel releases reported as Sec-CH-UA-Platform-Version
+var linuxKernelVersions = newWeighted([]entry[string]{
+	{"6.8.0", 0.30},
+	{"6.5.0", 0.20},
+	{"6.1.0", 0.20},
+	{"6.11.0", 0.15},
+	{"5.15.0", 0.15},
+})
+
+// ChromeOS platform version sent in the User-Agent since UA reduction
+const chromeOSFrozenVersion = "14541.0.0"
+
+// ChromeOS platform versions reported as Sec-CH-UA-Platform-Version
+var chromeOSPlatformVersions = newWeighted([]entry[string]{
+	{"16328.65.0", 0.35},
+	{"16295.54.0", 0.30},
+	{"16181.61.0", 0.20},
+	{"16093.68.0", 0.15},
+})
+
+// macOS releases behind the 10_15_7 token frozen by current browsers,
+// reported as Sec-CH-UA-Platform-Version (10_15_7 format)
+var macReleases = newWeighted([]entry[string]{
+	{"15_7", 0.30},    // Sequoia
+	{"26_1", 0.20},    // Tahoe
+	{"14_7_6", 0.18},  // Sonoma
+	{"15_6_1", 0.08},  // Sequoia
+	{"13_7_8", 0.10},  // Ventura
+	{"12_7_6", 0.06},  // Monterey
+	{"11_7_10", 0.04}, // Big Sur
+	{"10_15_7", 0.04}, // Catalina
+})
+
+// Share of Apple Silicon among macOS 11+ Macs. Apple Silicon Macs still
+// claim "Intel Mac OS X" in the User-Agent but report "arm" in Sec-CH-UA-Arch.
+var macArchs = newWeighted([]entry[string]{
+	{"arm", 0.7},
+	{"x86", 0.3},
+})
+
+// windowsPlatformVersion returns the platform version for a Windows NT version
+func (g *Generator) windowsPlatformVersion(nt string) string {
+	switch nt {
+	case "6.1":
+		return "0.1.0"
+	case "6.2":
+		return "0.2.0"
+	case "6.3":
+		return "0.3.0"
+	}
+	return windowsNT10PlatformVersions.pick(g.rng)
+}
+
+// macArch returns the CPU architecture for a macOS version (14_2_1 format)
+func (g *Generator) macArch(macVer string) string {
+	if strings.HasPrefix(macVer, "10_") {
+		return "x86"
+	}
+	return macArchs.pick(g.rng)
+}
+
+// linuxArch returns the CPU architecture and bitness for a Linux platform token
+func linuxArch(platform string) (arch, bitness string) {
+	switch {
+	case strings.HasSuffix(platform, "aarch64"):
+		return "arm", "64"
+	case strings.HasSuffix(platform, "i686"):
+		return "x86", "32"
+	}
+	return "x86", "64"
+}
+
+// dottedVersion converts an underscore version (14_2_1) into a
+// three-part dotted one (14.2.1), padding missing parts with zeros
+func dottedVersion(v string) string {
+	parts := strings.Split(v, "_")
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	return strings.Join(parts, ".")
+}
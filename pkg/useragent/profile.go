@@ -0,0 +1,256 @@
+package ua
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RequestKind is the kind of request a Profile builds headers for.
+// Browsers send different Accept and Sec-Fetch-* values for each.
+type RequestKind int
+
+const (
+	RequestDocument RequestKind = iota // top-level navigation typed into the address bar
+	RequestFetch                       // same-origin XHR or fetch()
+	RequestImage                       // same-origin <img>
+	RequestScript                      // same-origin <script>
+)
+
+// Profile is a User-Agent together with everything needed to send the
+// headers the same browser would send alongside it.
+type Profile struct {
+	UserAgent string
+
+	// Languages are the preferred languages, most preferred first.
+	// Rendered into Accept-Language with the browser's own q-value scheme.
+	Languages []string
+
+	// Hints are the client hints sent with every request,
+	// nil for browsers that do not support them.
+	Hints *ClientHints
+
+	engine Engine
+	major  int // major browser version (Safari version for WebKit)
+}
+
+// Header returns the headers the browser sends for a request of the given kind.
+// Hop-by-hop headers (Connection, TE) are left to the transport.
+func (p Profile) Header(kind RequestKind) http.Header {
+	h := make(http.Header, 12)
+
+	if p.Hints != nil {
+		p.Hints.Set(h)
+	}
+	if kind == RequestDocument && p.engine != EngineWebKit {
+		h.Set("Upgrade-Insecure-Requests", "1")
+	}
+	h.Set("User-Agent", p.UserAgent)
+	h.Set("Accept", p.accept(kind))
+
+	if p.sendsFetchMetadata() {
+		switch kind {
+		case RequestDocument:
+			h.Set("Sec-Fetch-Site", "none")
+			h.Set("Sec-Fetch-Mode", "navigate")
+			if p.engine != EngineWebKit { // WebKit never sends Sec-Fetch-User
+				h.Set("Sec-Fetch-User", "?1")
+			}
+			h.Set("Sec-Fetch-Dest", "document")
+		case RequestFetch:
+			h.Set("Sec-Fetch-Site", "same-origin")
+			h.Set("Sec-Fetch-Mode", "cors")
+			h.Set("Sec-Fetch-Dest", "empty")
+		case RequestImage:
+			h.Set("Sec-Fetch-Site", "same-origin")
+			h.Set("Sec-Fetch-Mode", "no-cors")
+			h.Set("Sec-Fetch-Dest", "image")
+		case RequestScript:
+			h.Set("Sec-Fetch-Site", "same-origin")
+			h.Set("Sec-Fetch-Mode", "no-cors")
+			h.Set("Sec-Fetch-Dest", "script")
+		}
+	}
+
+	h.Set("Accept-Encoding", p.acceptEncoding())
+	if len(p.Languages) > 0 {
+		h.Set("Accept-Language", p.acceptLanguage())
+	}
+	if kind == RequestDocument && p.sendsPriority() {
+		h.Set("Priority", "u=0, i")
+	}
+
+	return h
+}
+
+// accept returns the Accept header value for kind
+func (p Profile) accept(kind RequestKind) string {
+	switch kind {
+	case RequestDocument:
+		switch {
+		case p.engine == EngineBlink:
+			return "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7"
+		case p.engine == EngineGecko && p.major < 128:
+			return "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"
+		default:
+			return "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+		}
+	case RequestImage:
+		switch {
+		case p.engine == EngineBlink:
+			return "image/avif,image/webp,image/apng,image/svg+xml,image/*,*/*;q=0.8"
+		case p.engine == EngineGecko && p.major < 128:
+			return "image/avif,image/webp,*/*"
+		case p.engine == EngineGecko:
+			return "image/avif,image/webp,image/png,image/svg+xml,image/*;q=0.8,*/*;q=0.5"
+		case p.major < 17:
+			return "image/webp,image/avif,video/*;q=0.8,image/png,image/svg+xml,image/*;q=0.8,*/*;q=0.5"
+		default:
+			return "image/webp,image/avif,image/jxl,image/heic,image/heic-sequence,video/*;q=0.8,image/png,image/svg+xml,image/*;q=0.8,*/*;q=0.5"
+		}
+	}
+	return "*/*"
+}
+
+// acceptEncoding returns the Accept-Encoding header value.
+// zstd shipped in Chrome 123 and Firefox 126.
+func (p Profile) acceptEncoding() string {
+	switch {
+	case p.engine == EngineBlink && p.major >= 123,
+		p.engine == EngineGecko && p.major >= 126:
+		return "gzip, deflate, br, zstd"
+	}
+	return "gzip, deflate, br"
+}
+
+// acceptLanguage renders Languages with the browser's q-value scheme:
+// Firefox spreads q evenly over the list, Chrome and Safari step by 0.1.
+func (p Profile) acceptLanguage() string {
+	var b strings.Builder
+	n := len(p.Languages)
+	for i, lang := range p.Languages {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(lang)
+		if i == 0 {
+			continue
+		}
+
+		q := 10 - i // tenths
+		if p.engine == EngineGecko {
+			q = (20*(n-i) + n) / (2 * n) // round(10*(n-i)/n)
+		}
+		b.WriteString(";q=0.")
+		b.WriteString(strconv.Itoa(max(q, 1)))
+	}
+	return b.String()
+}
+
+// sendsFetchMetadata reports whether the browser sends Sec-Fetch-* headers.
+// Shipped in Chrome 76, Firefox 90 and Safari 16.4 (minor versions are
+// not tracked, so Safari 16 is treated as not sending them).
+func (p Profile) sendsFetchMetadata() bool {
+	switch p.engine {
+	case EngineBlink:
+		return p.major >= 76
+	case EngineGecko:
+		return p.major >= 90
+	}
+	return p.major >= 17
+}
+
+// sendsPriority reports whether the browser sends the Priority header
+// (RFC 9218) on navigations
+func (p Profile) sendsPriority() bool {
+	switch p.engine {
+	case EngineBlink:
+		return p.major >= 124
+	case EngineGecko:
+		return p.major >= 128
+	}
+	return p.major >= 17
+}
+
+// Preferred language lists, weighted by share of web traffic
+var languageSets = newWeighted([]entry[[]string]{
+	{[]string{"en-US", "en"}, 0.50},
+	{[]string{"en-GB", "en"}, 0.07},
+	{[]string{"de-DE", "de", "en-US", "en"}, 0.06},
+	{[]string{"fr-FR", "fr", "en-US", "en"}, 0.05},
+	{[]string{"es-ES", "es"}, 0.04},
+	{[]string{"pt-BR", "pt", "en-US", "en"}, 0.04},
+	{[]string{"ru-RU", "ru", "en-US", "en"}, 0.03},
+	{[]string{"ja-JP", "ja", "en-US", "en"}, 0.03},
+	{[]string{"zh-CN", "zh", "en"}, 0.03},
+	{[]string{"it-IT", "it", "en-US", "en"}, 0.03},
+	{[]string{"nl-NL", "nl", "en-US", "en"}, 0.02},
+	{[]string{"pl-PL", "pl", "en-US", "en"}, 0.02},
+	{[]string{"ko-KR", "ko", "en-US", "en"}, 0.02},
+	{[]string{"en-CA", "en-US", "en"}, 0.02},
+	{[]string{"en-AU", "en"}, 0.02},
+	{[]string{"tr-TR", "tr", "en-US", "en"}, 0.02},
+})
+
+// newProfile completes a Profile for id with a random language preference
+func (g *Generator) newProfile(id Identity) Profile {
+	p := Profile{
+		UserAgent: id.UserAgent,
+		Languages: slices.Clone(languageSets.pick(g.rng)),
+		engine:    id.Engine,
+	}
+	if id.hints.brand != "" {
+		hints := id.hints.hints()
+		p.Hints = &hints
+		p.major, _ = strconv.Atoi(majorVersion(id.hints.version))
+	} else {
+		p.major, _ = strconv.Atoi(majorVersion(id.BrowserVersion))
+	}
+	return p
+}
+
+// ChromeProfile generates a Chrome desktop Profile
+func (g *Generator) ChromeProfile() Profile {
+	return g.newProfile(g.chrome())
+}
+
+// ChromeAndroidProfile generates a Chrome Android Profile
+func (g *Generator) ChromeAndroidProfile() Profile {
+	return g.newProfile(g.chromeAndroid())
+}
+
+// EdgeProfile generates an Edge desktop Profile
+func (g *Generator) EdgeProfile() Profile {
+	return g.newProfile(g.edge())
+}
+
+// EdgeAndroidProfile generates an Edge Android Profile
+func (g *Generator) EdgeAndroidProfile() Profile {
+	return g.newProfile(g.edgeAndroid())
+}
+
+// SamsungBrowserProfile generates a Samsung Internet Profile
+func (g *Generator) SamsungBrowserProfile() Profile {
+	return g.newProfile(g.samsungBrowser())
+}
+
+// FirefoxProfile generates a Firefox desktop Profile
+func (g *Generator) FirefoxProfile() Profile {
+	return g.newProfile(g.firefox())
+}
+
+// FirefoxAndroidProfile generates a Firefox Android Profile
+func (g *Generator) FirefoxAndroidProfile() Profile {
+	return g.newProfile(g.firefoxAndroid())
+}
+
+// SafariProfile generates a Safari desktop Profile
+func (g *Generator) SafariProfile() Profile {
+	return g.newProfile(g.safari())
+}
+
+// SafariIOSProfile generates a Safari iPhone Profile
+func (g *Generator) SafariIOSProfile() Profile {
+	return g.newProfile(g.safariIOS())
+}
@@ -0,0 +1,148 @@
+package ua
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProfileHeaderSets(t *testing.T) {
+	g := WithSeed(42)
+
+	tests := []struct {
+		name    string
+		profile Profile
+		want    []string
+		absent  []string
+	}{
+		{"Chrome", g.ChromeProfile(),
+			[]string{"Sec-Ch-Ua", "Sec-Fetch-User", "Upgrade-Insecure-Requests"}, nil},
+		{"Firefox", g.FirefoxProfile(),
+			[]string{"Sec-Fetch-User", "Upgrade-Insecure-Requests"}, []string{"Sec-Ch-Ua"}},
+		{"Safari", g.SafariProfile(),
+			nil, []string{"Sec-Ch-Ua", "Sec-Fetch-User", "Upgrade-Insecure-Requests"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.profile
+			p.major = 140 // fixed so version gates do not interfere
+			if tt.name == "Safari" {
+				p.major = 18
+			}
+
+			h := p.Header(RequestDocument)
+			if h.Get("User-Agent") != p.UserAgent {
+				t.Errorf("User-Agent = %q, want %q", h.Get("User-Agent"), p.UserAgent)
+			}
+			for _, k := range tt.want {
+				if h.Get(k) == "" {
+					t.Errorf("missing %s in %v", k, h)
+				}
+			}
+			for _, k := range tt.absent {
+				if h.Get(k) != "" {
+					t.Errorf("unexpected %s: %s", k, h.Get(k))
+				}
+			}
+		})
+	}
+}
+
+func TestProfileRequestKinds(t *testing.T) {
+	p := WithSeed(42).ChromeProfile()
+	p.major = 140
+
+	tests := []struct {
+		kind   RequestKind
+		dest   string
+		mode   string
+		site   string
+		accept string
+	}{
+		{RequestDocument, "document", "navigate", "none", "text/html,"},
+		{RequestFetch, "empty", "cors", "same-origin", "*/*"},
+		{RequestImage, "image", "no-cors", "same-origin", "image/avif,"},
+		{RequestScript, "script", "no-cors", "same-origin", "*/*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dest, func(t *testing.T) {
+			h := p.Header(tt.kind)
+			if got := h.Get("Sec-Fetch-Dest"); got != tt.dest {
+				t.Errorf("Sec-Fetch-Dest = %q, want %q", got, tt.dest)
+			}
+			if got := h.Get("Sec-Fetch-Mode"); got != tt.mode {
+				t.Errorf("Sec-Fetch-Mode = %q, want %q", got, tt.mode)
+			}
+			if got := h.Get("Sec-Fetch-Site"); got != tt.site {
+				t.Errorf("Sec-Fetch-Site = %q, want %q", got, tt.site)
+			}
+			if got := h.Get("Accept"); !strings.HasPrefix(got, tt.accept) {
+				t.Errorf("Accept = %q, want prefix %q", got, tt.accept)
+			}
+			if tt.kind != RequestDocument && h.Get("Sec-Fetch-User") != "" {
+				t.Errorf("Sec-Fetch-User sent on subresource request")
+			}
+		})
+	}
+}
+
+func TestProfileAcceptEncoding(t *testing.T) {
+	tests := []struct {
+		engine Engine
+		major  int
+		want   string
+	}{
+		{EngineBlink, 122, "gzip, deflate, br"},
+		{EngineBlink, 123, "gzip, deflate, br, zstd"},
+		{EngineGecko, 125, "gzip, deflate, br"},
+		{EngineGecko, 126, "gzip, deflate, br, zstd"},
+		{EngineWebKit, 18, "gzip, deflate, br"},
+	}
+
+	for _, tt := range tests {
+		p := Profile{engine: tt.engine, major: tt.major}
+		if got := p.Header(RequestDocument).Get("Accept-Encoding"); got != tt.want {
+			t.Errorf("%s v%d: Accept-Encoding = %q, want %q", tt.engine, tt.major, got, tt.want)
+		}
+	}
+}
+
+func TestProfileAcceptLanguage(t *testing.T) {
+	langs := []string{"de-DE", "de", "en-US", "en"}
+
+	tests := []struct {
+		engine Engine
+		want   string
+	}{
+		{EngineBlink, "de-DE,de;q=0.9,en-US;q=0.8,en;q=0.7"},
+		{EngineGecko, "de-DE,de;q=0.8,en-US;q=0.5,en;q=0.3"},
+		{EngineWebKit, "de-DE,de;q=0.9,en-US;q=0.8,en;q=0.7"},
+	}
+
+	for _, tt := range tests {
+		p := Profile{Languages: langs, engine: tt.engine}
+		if got := p.Header(RequestDocument).Get("Accept-Language"); got != tt.want {
+			t.Errorf("%s: Accept-Language = %q, want %q", tt.engine, got, tt.want)
+		}
+	}
+
+	p := Profile{Languages: []string{"en-US", "en"}, engine: EngineGecko}
+	if got := p.Header(RequestDocument).Get("Accept-Language"); got != "en-US,en;q=0.5" {
+		t.Errorf("Firefox en-US: Accept-Language = %q", got)
+	}
+}
+
+func TestProfileHintsMatchUA(t *testing.T) {
+	p := ChromeAndroidProfile()
+	if p.Hints == nil {
+		t.Fatal("Chrome Android profile has no client hints")
+	}
+	if h := p.Header(RequestImage); h.Get("Sec-CH-UA-Mobile") != "?1" {
+		t.Errorf("Sec-CH-UA-Mobile = %q, want ?1", h.Get("Sec-CH-UA-Mobile"))
+	}
+
+	if FirefoxProfile().Hints != nil || SafariIOSProfile().Hints != nil {
+		t.Error("non-Chromium profile has client hints")
+	}
+}
@@ -0,0 +1,127 @@
+package ua
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// ErrNoMatch is returned by Generate when no User-Agent satisfies the query
+var ErrNoMatch = errors.New("ua: no User-Agent matches the query")
+
+// Query restricts the User-Agents returned by Generate.
+// Empty fields match anything.
+type Query struct {
+	Browsers []Browser
+	OS       []OS
+	Types    []UAType // device classes; bots are never generated
+
+	// MinMajor and MaxMajor bound the browser major version, 0 for no bound.
+	MinMajor int
+	MaxMajor int
+
+	// ExcludeVersions lists browser versions never to return, either
+	// exactly as sent ("142.0.0.0") or as a bare major ("142").
+	ExcludeVersions []string
+}
+
+// target is a browser, OS and device class combination that can be generated
+type target struct {
+	browser Browser
+	os      OS
+	typ     UAType
+	gen     func(*Generator) Identity
+}
+
+// Every combination Generate can produce
+var targets = []target{
+	{BrowserChrome, OSWindows, TypeDesktop, (*Generator).chromeWindows},
+	{BrowserChrome, OSMacOS, TypeDesktop, (*Generator).chromeMac},
+	{BrowserChrome, OSLinux, TypeDesktop, (*Generator).chromeLinux},
+	{BrowserChrome, OSChromeOS, TypeDesktop, (*Generator).chromeOS},
+	{BrowserChrome, OSAndroid, TypeMobile, (*Generator).chromeAndroid},
+	{BrowserChrome, OSIOS, TypeMobile, (*Generator).chromeIOS},
+	{BrowserFirefox, OSWindows, TypeDesktop, (*Generator).firefoxWindows},
+	{BrowserFirefox, OSMacOS, TypeDesktop, (*Generator).firefoxMac},
+	{BrowserFirefox, OSLinux, TypeDesktop, (*Generator).firefoxLinux},
+	{BrowserFirefox, OSAndroid, TypeMobile, (*Generator).firefoxAndroid},
+	{BrowserSafari, OSMacOS, TypeDesktop, (*Generator).safari},
+	{BrowserSafari, OSIOS, TypeMobile, (*Generator).safariIOS},
+	{BrowserSafari, OSIOS, TypeTablet, (*Generator).safariIPad},
+	{BrowserEdge, OSWindows, TypeDesktop, (*Generator).edgeWindows},
+	{BrowserEdge, OSMacOS, TypeDesktop, (*Generator).edgeMac},
+	{BrowserEdge, OSAndroid, TypeMobile, (*Generator).edgeAndroid},
+	{BrowserSamsung, OSAndroid, TypeMobile, (*Generator).samsungBrowser},
+	{BrowserWebView, OSAndroid, TypeMobile, (*Generator).androidWebView},
+}
+
+// Generate returns a random Identity satisfying q. Matching browser, OS
+// and device combinations are picked uniformly, versions by popularity.
+// The error wraps ErrNoMatch if nothing satisfies q.
+func (g *Generator) Generate(q Query) (Identity, error) {
+	g.refresh()
+
+	var matched []target
+	for _, t := range targets {
+		if (len(q.Browsers) == 0 || slices.Contains(q.Browsers, t.browser)) &&
+			(len(q.OS) == 0 || slices.Contains(q.OS, t.os)) &&
+			(len(q.Types) == 0 || slices.Contains(q.Types, t.typ)) {
+			matched = append(matched, t)
+		}
+	}
+	if len(matched) == 0 {
+		return Identity{}, fmt.Errorf("%w: no browser %v on %v as %v", ErrNoMatch, q.Browsers, q.OS, q.Types)
+	}
+
+	if !q.filtersVersions() {
+		return matched[g.rng.intn(len(matched))].gen(g), nil
+	}
+
+	// Restrict the versions of each matched browser, dropping browsers
+	// left without any. Browsers sharing a table are filtered once.
+	t := *g.t
+	filtered := make(map[*weighted[string]]bool, len(matched))
+	n := 0
+	for _, m := range matched {
+		versions := t.versions(m.browser)
+		ok, seen := filtered[versions]
+		if !seen {
+			*versions, ok = versions.filter(q.allows)
+			filtered[versions] = ok
+		}
+		if ok {
+			matched[n] = m
+			n++
+		}
+	}
+	if n == 0 {
+		return Identity{}, fmt.Errorf("%w: no %v version in the requested range", ErrNoMatch, q.Browsers)
+	}
+
+	sub := *g
+	sub.t, sub.pinned = &t, true
+	return matched[sub.rng.intn(n)].gen(&sub), nil
+}
+
+// filtersVersions reports whether q restricts browser versions
+func (q *Query) filtersVersions() bool {
+	return q.MinMajor > 0 || q.MaxMajor > 0 || len(q.ExcludeVersions) > 0
+}
+
+// allows reports whether browser version v passes the version filters of q
+func (q *Query) allows(v string) bool {
+	major, err := strconv.Atoi(majorVersion(v))
+	if err != nil {
+		return false
+	}
+	if (q.MinMajor > 0 && major < q.MinMajor) || (q.MaxMajor > 0 && major > q.MaxMajor) {
+		return false
+	}
+	for _, x := range q.ExcludeVersions {
+		if x == v || x == majorVersion(v) {
+			return false
+		}
+	}
+	return true
+}
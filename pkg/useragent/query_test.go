@@ -0,0 +1,93 @@
+package ua
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestGenerateFilters(t *testing.T) {
+	g := WithSeed(42)
+
+	tests := []struct {
+		name string
+		q    Query
+	}{
+		{"any", Query{}},
+		{"Chrome or Edge on Windows or macOS", Query{Browsers: []Browser{BrowserChrome, BrowserEdge}, OS: []OS{OSWindows, OSMacOS}}},
+		{"mobile", Query{Types: []UAType{TypeMobile}}},
+		{"tablet", Query{Types: []UAType{TypeTablet}}},
+		{"Firefox on Linux", Query{Browsers: []Browser{BrowserFirefox}, OS: []OS{OSLinux}}},
+		{"Edge on macOS", Query{Browsers: []Browser{BrowserEdge}, OS: []OS{OSMacOS}}},
+		{"Chrome on ChromeOS", Query{Browsers: []Browser{BrowserChrome}, OS: []OS{OSChromeOS}}},
+		{"recent Chrome", Query{Browsers: []Browser{BrowserChrome}, MinMajor: 140}},
+		{"Chrome range", Query{Browsers: []Browser{BrowserChrome, BrowserWebView}, MinMajor: 138, MaxMajor: 141}},
+		{"excluded versions", Query{Browsers: []Browser{BrowserFirefox}, ExcludeVersions: []string{"146.0", "145"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				id, err := g.Generate(tt.q)
+				if err != nil {
+					t.Fatal(err)
+				}
+				q := tt.q
+				if len(q.Browsers) > 0 && !slices.Contains(q.Browsers, id.Browser) {
+					t.Fatalf("browser %q not in %v: %s", id.Browser, q.Browsers, id.UserAgent)
+				}
+				if len(q.OS) > 0 && !slices.Contains(q.OS, id.OS) {
+					t.Fatalf("OS %q not in %v: %s", id.OS, q.OS, id.UserAgent)
+				}
+				if len(q.Types) > 0 && !slices.Contains(q.Types, id.Type) {
+					t.Fatalf("type %v not in %v: %s", id.Type, q.Types, id.UserAgent)
+				}
+				major, _ := strconv.Atoi(majorVersion(id.BrowserVersion))
+				if q.MinMajor > 0 && major < q.MinMajor || q.MaxMajor > 0 && major > q.MaxMajor {
+					t.Fatalf("major %d outside [%d, %d]: %s", major, q.MinMajor, q.MaxMajor, id.UserAgent)
+				}
+				if slices.Contains(q.ExcludeVersions, id.BrowserVersion) || slices.Contains(q.ExcludeVersions, majorVersion(id.BrowserVersion)) {
+					t.Fatalf("excluded version %s returned", id.BrowserVersion)
+				}
+
+				u := Parse(id.UserAgent)
+				if u.Browser != id.Browser || u.OS != id.OS || (u.OSVersion != id.OSVersion && !id.Reduced) || u.Type != id.Type {
+					t.Fatalf("Parse(%q) = %+v, identity %+v", id.UserAgent, u, id)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateNoMatch(t *testing.T) {
+	g := WithSeed(1)
+
+	queries := []Query{
+		{Browsers: []Browser{BrowserSafari}, OS: []OS{OSWindows}},
+		{Browsers: []Browser{BrowserSamsung}, Types: []UAType{TypeDesktop}},
+		{Types: []UAType{TypeBot}},
+		{Browsers: []Browser{BrowserOpera}},
+		{Browsers: []Browser{BrowserChrome}, MinMajor: 1000},
+		{MinMajor: 150, MaxMajor: 140},
+	}
+	for _, q := range queries {
+		if id, err := g.Generate(q); !errors.Is(err, ErrNoMatch) {
+			t.Errorf("Generate(%+v) = %q, %v; want ErrNoMatch", q, id.UserAgent, err)
+		}
+	}
+}
+
+func TestGenerateDoesNotFilterDefaults(t *testing.T) {
+	g := WithSeed(3)
+	if _, err := g.Generate(Query{Browsers: []Browser{BrowserChrome}, MinMajor: 1000}); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := g.Generate(Query{Browsers: []Browser{BrowserChrome}, MinMajor: 130}); err != nil {
+		t.Fatal(err)
+	}
+	// Filtered queries must not leave their restrictions behind
+	if g.t != defaultTables || len(defaultTables.chrome.values) != len(chromeVersions.values) {
+		t.Fatal("Generate modified the shared tables")
+	}
+}
@@ -18,14 +18,133 @@ func (x *xorshift64) next() uint64 {
 	return x.state
 }
 
+// mix64 is the SplitMix64 finalizer: it spreads nearby inputs, such as
+// consecutive seeds, over the whole state space
+func mix64(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
 // intn returns pseudo-random int in [0, n)
 func (x *xorshift64) intn(n int) int {
 	if n <= 0 { return 0 }
 	return int(x.next() % uint64(n))
 }
 
-// pick returns random element from slice s.
-// s must be non-empty.
-func pick[T any](x *xorshift64, s []T) T {
-	return s[x.intn(len(s))]
+// float64 returns pseudo-random float64 in [0, 1)
+func (x *xorshift64) float64() float64 {
+	return float64(x.next()>>11) / (1 << 53)
+}
+
+// entry is a value paired with its sampling weight, as emitted into data.go
+type entry[T any] struct {
+	value  T
+	weight float64
+}
+
+// weighted is a list of values sampled in proportion to their weights.
+// Sampling is O(1) using Vose's alias method.
+type weighted[T any] struct {
+	values  []T
+	weights []float64 // as given to newWeighted, kept for filter
+	prob    []float64 // probability of keeping column i
+	alias   []int     // column to fall back to when i is rejected
+}
+
+// newWeighted builds an alias table from entries.
+// Non-positive weights are never picked unless all weights are
+// non-positive, in which case sampling is uniform.
+func newWeighted[T any](entries []entry[T]) weighted[T] {
+	n := len(entries)
+	w := weighted[T]{
+		values:  make([]T, n),
+		weights: make([]float64, n),
+		prob:    make([]float64, n),
+		alias:   make([]int, n),
+	}
+
+	var total float64
+	for i, e := range entries {
+		w.values[i] = e.value
+		w.weights[i] = e.weight
+		if e.weight > 0 {
+			total += e.weight
+		}
+	}
+
+	scaled := make([]float64, n)
+	for i, e := range entries {
+		switch {
+		case total <= 0:
+			scaled[i] = 1
+		case e.weight > 0:
+			scaled[i] = e.weight * float64(n) / total
+		}
+	}
+
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i, p := range scaled {
+		if p < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+
+		w.prob[s] = scaled[s]
+		w.alias[s] = l
+
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+
+	// Leftovers are 1 up to floating point error
+	for _, i := range large {
+		w.prob[i] = 1
+		w.alias[i] = i
+	}
+	for _, i := range small {
+		w.prob[i] = 1
+		w.alias[i] = i
+	}
+
+	return w
+}
+
+// pick returns a random value with probability proportional to its weight.
+// w must be non-empty.
+func (w *weighted[T]) pick(x *xorshift64) T {
+	i := x.intn(len(w.values))
+	if x.float64() >= w.prob[i] {
+		i = w.alias[i]
+	}
+	return w.values[i]
+}
+
+// filter returns a table of the values keep accepts, with their original
+// weights. ok is false if no value is accepted.
+func (w *weighted[T]) filter(keep func(T) bool) (f weighted[T], ok bool) {
+	entries := make([]entry[T], 0, len(w.values))
+	for i, v := range w.values {
+		if keep(v) {
+			entries = append(entries, entry[T]{v, w.weights[i]})
+		}
+	}
+	if len(entries) == 0 {
+		return weighted[T]{}, false
+	}
+	return newWeighted(entries), true
 }
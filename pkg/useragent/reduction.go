@@ -0,0 +1,71 @@
+package ua
+
+// Chromium User-Agent reduction milestones
+// (https://www.chromium.org/updates/ua-reduction/)
+const (
+	reducedMinorSince   = 101 // MINOR.BUILD.PATCH sent as 0.0.0
+	reducedDesktopSince = 107 // desktop platform and OS version frozen
+	reducedAndroidSince = 110 // Android version and device model frozen
+)
+
+// Frozen platform tokens sent by reduced Chromium User-Agents
+const (
+	reducedWindows = "Windows NT 10.0; Win64; x64"
+	reducedMac     = "Macintosh; Intel Mac OS X " + macOSFrozen
+	reducedLinux   = "X11; Linux x86_64"
+	reducedAndroid = "Linux; Android 10; K"
+)
+
+// Firefox 87+ reports macOS 10.15 and later as 10.15. Earlier releases
+// saw macOS 11 and later through the compatibility version 10.16.
+const (
+	firefoxFrozenMacSince = 87
+	firefoxFrozenMac      = "10.15"
+	firefoxCompatMac      = "10.16"
+)
+
+// WithLegacyUA renders User-Agents the way browsers did before freezing
+// them: Chrome and Edge send real Windows, macOS and Android versions,
+// device models and the full version from the usage data, Edge sends the
+// full Chromium version it is built on, and Firefox sends the real macOS
+// release. macOS 11 and later still read 10_15_7 in Chrome, Edge and
+// Safari, which froze it before UA reduction, and 10.16 in Firefox.
+// Identities are unchanged, only the strings differ.
+func WithLegacyUA() Option {
+	return func(g *Generator) {
+		g.legacy = true
+	}
+}
+
+// reduces reports whether version sends the User-Agent frozen since milestone
+func (g *Generator) reduces(version string, since int) bool {
+	return !g.legacy && majorNumber(version) >= since
+}
+
+// sentVersion returns a Chromium version as sent in the User-Agent
+func (g *Generator) sentVersion(version string) string {
+	if major := majorVersion(version); g.reduces(version, reducedMinorSince) && version[len(major):] != ".0.0.0" {
+		return major + ".0.0.0"
+	}
+	return version
+}
+
+// macRelease picks the macOS release (10_15_7 format) for version of
+// browser b. A frozen token in the usage data stands for any later
+// release, so the real one is picked from the releases b runs on.
+func (g *Generator) macRelease(p *platforms, b Browser, version string) string {
+	token := p.mac.pick(g.rng)
+	if token == macOSFrozen && freezesMacOS(b, majorNumber(version)) && len(p.macReleases.values) > 0 {
+		return p.macReleases.pick(g.rng)
+	}
+	return token
+}
+
+// macToken returns the macOS version browser major sends from release.
+// Safari 14+, Chrome and Edge report macOS 11 and later as 10_15_7.
+func macToken(b Browser, major int, release string) string {
+	if ord, ok := macOrdinal(release); ok && ord >= 6 && freezesMacOS(b, major) {
+		return macOSFrozen
+	}
+	return release
+}
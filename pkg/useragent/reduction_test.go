@@ -0,0 +1,156 @@
+package ua
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReducedChromium(t *testing.T) {
+	g := WithSeed(11)
+
+	platforms := map[OS]string{
+		OSWindows: "(" + reducedWindows + ")",
+		OSMacOS:   "(" + reducedMac + ")",
+		OSAndroid: "(" + reducedAndroid + ")",
+	}
+
+	for i := 0; i < 500; i++ {
+		for _, id := range []Identity{g.ChromeIdentity(), g.EdgeIdentity(), g.ChromeAndroidIdentity(), g.EdgeAndroidIdentity()} {
+			major := majorNumber(id.BrowserVersion)
+			if major >= reducedMinorSince && !strings.HasSuffix(id.BrowserVersion, ".0.0.0") {
+				t.Fatalf("%s %s sends its minor version: %s", id.Browser, id.BrowserVersion, id.UserAgent)
+			}
+
+			since := reducedDesktopSince
+			if id.OS == OSAndroid {
+				since = reducedAndroidSince
+			}
+			if major < since {
+				continue
+			}
+			if !id.Reduced {
+				t.Fatalf("%s %s on %s not marked reduced: %s", id.Browser, id.BrowserVersion, id.OS, id.UserAgent)
+			}
+			if want, ok := platforms[id.OS]; ok && !strings.Contains(id.UserAgent, want) {
+				t.Fatalf("%s %s on %s without %s: %s", id.Browser, id.BrowserVersion, id.OS, want, id.UserAgent)
+			}
+			if id.OS == OSAndroid && (id.DeviceModel == "K" || id.OSVersion == "") {
+				t.Fatalf("reduced identity lost the real device: %+v", id)
+			}
+		}
+	}
+}
+
+// Reduction only changes what is sent: platforms are still looked up by
+// the full build picked from the data
+func TestReducedFullBuildPlatforms(t *testing.T) {
+	d := &Dataset{
+		Chrome:    []VersionWeight{{"109.0.5414.120", 1}},
+		Windows:   []VersionWeight{{"10.0", 1}, {"6.1", 1}},
+		BrowserOS: []BrowserOSWeight{{"chrome", "109.0.5414.120", "windows", "6.1", 1}},
+	}
+	for _, legacy := range []bool{false, true} {
+		opts := []Option{WithDataset(d)}
+		if legacy {
+			opts = append(opts, WithLegacyUA())
+		}
+		g := WithSeed(3, opts...)
+		for i := 0; i < 200; i++ {
+			if id := g.ChromeWindowsIdentity(); id.OSVersion != "6.1" {
+				t.Fatalf("legacy %v: Chrome %s on Windows %s, want the joint 6.1", legacy, id.BrowserVersion, id.OSVersion)
+			}
+		}
+		if allocs := testing.AllocsPerRun(100, func() { g.ChromeWindows() }); allocs > 2 {
+			t.Errorf("legacy %v: %v allocs per User-Agent, platforms rebuilt on every call", legacy, allocs)
+		}
+	}
+}
+
+func TestReducedHintsKeepRealValues(t *testing.T) {
+	g := WithSeed(5)
+
+	for i := 0; i < 200; i++ {
+		id := g.ChromeAndroidIdentity()
+		hints, _ := id.ClientHints()
+		he := hints.HighEntropy()
+		if he.Model != `"`+id.DeviceModel+`"` {
+			t.Fatalf("model hint %s, want %q for %s", he.Model, id.DeviceModel, id.UserAgent)
+		}
+		if he.PlatformVersion != `"`+id.OSVersion+`.0.0"` {
+			t.Fatalf("platform version hint %s, want Android %s", he.PlatformVersion, id.OSVersion)
+		}
+	}
+}
+
+func TestLegacyUA(t *testing.T) {
+	g := WithSeed(11, WithLegacyUA())
+
+	for i := 0; i < 500; i++ {
+		for _, id := range []Identity{g.ChromeIdentity(), g.EdgeIdentity(), g.ChromeAndroidIdentity(), g.FirefoxMacIdentity()} {
+			u := Parse(id.UserAgent)
+			if id.OS == OSAndroid && (u.DeviceModel != id.DeviceModel || u.OSVersion != id.OSVersion) {
+				t.Fatalf("legacy Android UA %q, identity %+v", id.UserAgent, id)
+			}
+			if id.Reduced && id.OS != OSMacOS { // macOS 11+ was frozen before reduction
+				t.Fatalf("legacy identity marked reduced: %s", id.UserAgent)
+			}
+			if id.OS == OSWindows && u.OSVersion != id.OSVersion {
+				t.Fatalf("legacy Windows UA %q, identity %+v", id.UserAgent, id)
+			}
+			if id.Browser == BrowserFirefox && u.OSVersion != firefoxMacSent(id.OSVersion) {
+				t.Fatalf("legacy Firefox UA %q, identity %+v", id.UserAgent, id)
+			}
+		}
+	}
+}
+
+// firefoxMacSent returns the macOS version unfrozen Firefox sends from
+// the real one
+func firefoxMacSent(osVersion string) string {
+	if compareVersions(osVersion, "11") >= 0 {
+		return firefoxCompatMac
+	}
+	return majorMinor(osVersion, ".")
+}
+
+func TestFirefoxFrozenMac(t *testing.T) {
+	g := WithSeed(3)
+
+	for i := 0; i < 500; i++ {
+		id := g.FirefoxMacIdentity()
+		u := Parse(id.UserAgent)
+		frozen := majorNumber(id.BrowserVersion) >= firefoxFrozenMacSince && compareVersions(id.OSVersion, firefoxFrozenMac) >= 0
+		switch {
+		case frozen && u.OSVersion != firefoxFrozenMac:
+			t.Fatalf("Firefox %s on macOS %s sends %s", id.BrowserVersion, id.OSVersion, u.OSVersion)
+		case !frozen && u.OSVersion != firefoxMacSent(id.OSVersion):
+			t.Fatalf("Firefox %s on macOS %s sends %s", id.BrowserVersion, id.OSVersion, u.OSVersion)
+		case id.Reduced != (u.OSVersion != id.OSVersion):
+			t.Fatalf("Reduced = %v for %+v", id.Reduced, id)
+		}
+	}
+}
+
+func TestLegacyEdgeChromium(t *testing.T) {
+	d := &Dataset{
+		Chrome: []VersionWeight{{"99.0.4844.51", 1}, {"142.0.0.0", 1}},
+		Edge:   []VersionWeight{{"99.0.1150.36", 1}, {"109.0.1518.78", 1}, {"142.0.0.0", 1}},
+	}
+	want := map[string]string{
+		"99.0.1150.36":  "99.0.4844.51",  // from the Chrome data
+		"109.0.1518.78": "109.0.5414.78", // branch build
+		"142.0.0.0":     "142.0.0.0",     // reduced in the data
+	}
+	legacy := WithSeed(4, WithDataset(d), WithLegacyUA())
+	reduced := WithSeed(4, WithDataset(d))
+	for i := 0; i < 200; i++ {
+		id := legacy.EdgeIdentity()
+		if chrome, _ := tokenVersion(id.UserAgent, "Chrome/"); chrome != want[id.BrowserVersion] {
+			t.Fatalf("legacy Edge %s sends Chrome/%s, want %s", id.BrowserVersion, chrome, want[id.BrowserVersion])
+		}
+		id = reduced.EdgeIdentity()
+		if chrome, _ := tokenVersion(id.UserAgent, "Chrome/"); !strings.HasSuffix(chrome, ".0.0.0") {
+			t.Fatalf("Edge %s sends Chrome/%s", id.BrowserVersion, chrome)
+		}
+	}
+}
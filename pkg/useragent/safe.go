@@ -0,0 +1,479 @@
+package ua
+
+import "sync"
+
+// SafeGenerator has the methods of Generator and is safe for concurrent
+// use: each call holds a lock around the Generator's. Concurrent callers
+// share one sequence, so who gets which User-Agent depends on scheduling;
+// callers that need reproducible results take a Stream of their own.
+type SafeGenerator struct {
+	mu sync.Mutex
+	g  *Generator
+}
+
+// NewSafe returns a SafeGenerator drawing from g, which must not be used
+// elsewhere afterwards
+//
+//	s := ua.NewSafe(ua.WithSeed(12345, ua.MustSnapshot("2026-01-18")))
+func NewSafe(g *Generator) *SafeGenerator {
+	return &SafeGenerator{g: g}
+}
+
+// streamSalt separates the seeds of streams from those of ForKey keys
+const streamSalt = 0x73747265616d // "stream"
+
+// Stream returns a Generator of its own for the caller named name, with
+// the options and data of s and a seed derived from the seed of s and
+// name. A caller gets the same sequence however the others are scheduled,
+// and each call starts it over. Like any Generator, a stream is for one
+// goroutine at a time.
+//
+//	go func() { g := s.Stream("worker-1"); ... }()
+func (s *SafeGenerator) Stream(name string) *Generator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g := *s.g
+	g.rng = newXorshift64(keySeed(s.g.seed^streamSalt, name))
+	return &g
+}
+
+// Clone creates a SafeGenerator with a copy of the state of s
+func (s *SafeGenerator) Clone() *SafeGenerator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &SafeGenerator{g: s.g.Clone()}
+}
+
+// Random returns a random User-Agent from any category
+func (s *SafeGenerator) Random() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.Random()
+}
+
+// RandomDesktop returns a random desktop browser User-Agent
+func (s *SafeGenerator) RandomDesktop() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.RandomDesktop()
+}
+
+// RandomMobile returns a random mobile browser User-Agent
+func (s *SafeGenerator) RandomMobile() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.RandomMobile()
+}
+
+// RandomBot returns a random bot User-Agent
+func (s *SafeGenerator) RandomBot() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.RandomBot()
+}
+
+// State returns the internal PRNG state for debugging/serialization
+func (s *SafeGenerator) State() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.State()
+}
+
+// SafariIOS generates a Safari User-Agent for iPhone
+func (s *SafeGenerator) SafariIOS() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SafariIOS()
+}
+
+// SafariIPad generates a Safari User-Agent for iPad
+func (s *SafeGenerator) SafariIPad() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SafariIPad()
+}
+
+// ChromeIOS generates a Chrome User-Agent for iPhone
+func (s *SafeGenerator) ChromeIOS() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeIOS()
+}
+
+// ChromeAndroid generates a Chrome User-Agent for Android
+func (s *SafeGenerator) ChromeAndroid() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeAndroid()
+}
+
+// AndroidWebView generates an Android WebView User-Agent
+func (s *SafeGenerator) AndroidWebView() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.AndroidWebView()
+}
+
+// FirefoxAndroid generates a Firefox User-Agent for Android
+func (s *SafeGenerator) FirefoxAndroid() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.FirefoxAndroid()
+}
+
+// SamsungBrowser generates a Samsung Internet User-Agent
+func (s *SafeGenerator) SamsungBrowser() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SamsungBrowser()
+}
+
+// EdgeAndroid generates an Edge User-Agent for Android
+func (s *SafeGenerator) EdgeAndroid() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.EdgeAndroid()
+}
+
+// Chrome generates a Chrome User-Agent for random desktop OS
+func (s *SafeGenerator) Chrome() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.Chrome()
+}
+
+// ChromeWindows generates a Chrome User-Agent for Windows
+func (s *SafeGenerator) ChromeWindows() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeWindows()
+}
+
+// ChromeMac generates a Chrome User-Agent for macOS
+func (s *SafeGenerator) ChromeMac() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeMac()
+}
+
+// ChromeLinux generates a Chrome User-Agent for Linux
+func (s *SafeGenerator) ChromeLinux() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeLinux()
+}
+
+// Firefox generates a Firefox desktop User-Agent
+func (s *SafeGenerator) Firefox() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.Firefox()
+}
+
+// FirefoxWindows generates a Firefox User-Agent for Windows
+func (s *SafeGenerator) FirefoxWindows() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.FirefoxWindows()
+}
+
+// FirefoxMac generates a Firefox User-Agent for macOS
+func (s *SafeGenerator) FirefoxMac() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.FirefoxMac()
+}
+
+// Safari generates a Safari desktop User-Agent (macOS only)
+func (s *SafeGenerator) Safari() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.Safari()
+}
+
+// Edge generates an Edge desktop User-Agent
+func (s *SafeGenerator) Edge() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.Edge()
+}
+
+// EdgeWindows generates an Edge User-Agent for Windows
+func (s *SafeGenerator) EdgeWindows() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.EdgeWindows()
+}
+
+// ChromeWithHints generates a Chrome desktop User-Agent and its client hints
+func (s *SafeGenerator) ChromeWithHints() (string, ClientHints) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeWithHints()
+}
+
+// ChromeAndroidWithHints generates a Chrome Android User-Agent and its client hints
+func (s *SafeGenerator) ChromeAndroidWithHints() (string, ClientHints) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeAndroidWithHints()
+}
+
+// EdgeWithHints generates an Edge desktop User-Agent and its client hints
+func (s *SafeGenerator) EdgeWithHints() (string, ClientHints) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.EdgeWithHints()
+}
+
+// EdgeAndroidWithHints generates an Edge Android User-Agent and its client hints
+func (s *SafeGenerator) EdgeAndroidWithHints() (string, ClientHints) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.EdgeAndroidWithHints()
+}
+
+// SamsungBrowserWithHints generates a Samsung Internet User-Agent and its client hints
+func (s *SafeGenerator) SamsungBrowserWithHints() (string, ClientHints) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SamsungBrowserWithHints()
+}
+
+// ChromeIdentity generates a Chrome desktop Identity
+func (s *SafeGenerator) ChromeIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeIdentity()
+}
+
+// ChromeWindowsIdentity generates a Chrome Identity for Windows
+func (s *SafeGenerator) ChromeWindowsIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeWindowsIdentity()
+}
+
+// ChromeMacIdentity generates a Chrome Identity for macOS
+func (s *SafeGenerator) ChromeMacIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeMacIdentity()
+}
+
+// ChromeLinuxIdentity generates a Chrome Identity for Linux
+func (s *SafeGenerator) ChromeLinuxIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeLinuxIdentity()
+}
+
+// FirefoxIdentity generates a Firefox desktop Identity
+func (s *SafeGenerator) FirefoxIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.FirefoxIdentity()
+}
+
+// FirefoxWindowsIdentity generates a Firefox Identity for Windows
+func (s *SafeGenerator) FirefoxWindowsIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.FirefoxWindowsIdentity()
+}
+
+// FirefoxMacIdentity generates a Firefox Identity for macOS
+func (s *SafeGenerator) FirefoxMacIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.FirefoxMacIdentity()
+}
+
+// SafariIdentity generates a Safari desktop Identity
+func (s *SafeGenerator) SafariIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SafariIdentity()
+}
+
+// EdgeIdentity generates an Edge desktop Identity
+func (s *SafeGenerator) EdgeIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.EdgeIdentity()
+}
+
+// EdgeWindowsIdentity generates an Edge Identity for Windows
+func (s *SafeGenerator) EdgeWindowsIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.EdgeWindowsIdentity()
+}
+
+// SafariIOSIdentity generates a Safari Identity for iPhone
+func (s *SafeGenerator) SafariIOSIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SafariIOSIdentity()
+}
+
+// SafariIPadIdentity generates a Safari Identity for iPad
+func (s *SafeGenerator) SafariIPadIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SafariIPadIdentity()
+}
+
+// ChromeIOSIdentity generates a Chrome Identity for iPhone
+func (s *SafeGenerator) ChromeIOSIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeIOSIdentity()
+}
+
+// ChromeAndroidIdentity generates a Chrome Identity for Android
+func (s *SafeGenerator) ChromeAndroidIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeAndroidIdentity()
+}
+
+// AndroidWebViewIdentity generates an Android WebView Identity
+func (s *SafeGenerator) AndroidWebViewIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.AndroidWebViewIdentity()
+}
+
+// FirefoxAndroidIdentity generates a Firefox Identity for Android
+func (s *SafeGenerator) FirefoxAndroidIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.FirefoxAndroidIdentity()
+}
+
+// SamsungBrowserIdentity generates a Samsung Internet Identity
+func (s *SafeGenerator) SamsungBrowserIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SamsungBrowserIdentity()
+}
+
+// EdgeAndroidIdentity generates an Edge Identity for Android
+func (s *SafeGenerator) EdgeAndroidIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.EdgeAndroidIdentity()
+}
+
+// RandomIdentity returns a random Identity from any category
+func (s *SafeGenerator) RandomIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.RandomIdentity()
+}
+
+// RandomDesktopIdentity returns a random desktop browser Identity
+func (s *SafeGenerator) RandomDesktopIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.RandomDesktopIdentity()
+}
+
+// RandomMobileIdentity returns a random mobile browser Identity
+func (s *SafeGenerator) RandomMobileIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.RandomMobileIdentity()
+}
+
+// RandomBotIdentity returns a random bot Identity
+func (s *SafeGenerator) RandomBotIdentity() Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.RandomBotIdentity()
+}
+
+// ChromeProfile generates a Chrome desktop Profile
+func (s *SafeGenerator) ChromeProfile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeProfile()
+}
+
+// ChromeAndroidProfile generates a Chrome Android Profile
+func (s *SafeGenerator) ChromeAndroidProfile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ChromeAndroidProfile()
+}
+
+// EdgeProfile generates an Edge desktop Profile
+func (s *SafeGenerator) EdgeProfile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.EdgeProfile()
+}
+
+// EdgeAndroidProfile generates an Edge Android Profile
+func (s *SafeGenerator) EdgeAndroidProfile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.EdgeAndroidProfile()
+}
+
+// SamsungBrowserProfile generates a Samsung Internet Profile
+func (s *SafeGenerator) SamsungBrowserProfile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SamsungBrowserProfile()
+}
+
+// FirefoxProfile generates a Firefox desktop Profile
+func (s *SafeGenerator) FirefoxProfile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.FirefoxProfile()
+}
+
+// FirefoxAndroidProfile generates a Firefox Android Profile
+func (s *SafeGenerator) FirefoxAndroidProfile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.FirefoxAndroidProfile()
+}
+
+// SafariProfile generates a Safari desktop Profile
+func (s *SafeGenerator) SafariProfile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SafariProfile()
+}
+
+// SafariIOSProfile generates a Safari iPhone Profile
+func (s *SafeGenerator) SafariIOSProfile() Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.SafariIOSProfile()
+}
+
+// Generate returns a random Identity satisfying q, see Generator.Generate
+func (s *SafeGenerator) Generate(q Query) (Identity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.Generate(q)
+}
+
+// ForKey returns the Identity of key, see Generator.ForKey
+func (s *SafeGenerator) ForKey(key string) Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.ForKey(key)
+}
+
+// DataInfo describes the dataset s samples from
+func (s *SafeGenerator) DataInfo() DatasetInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.DataInfo()
+}
@@ -0,0 +1,111 @@
+package ua
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSafeGeneratorMethods(t *testing.T) {
+	signature := func(m reflect.Method) []reflect.Type {
+		var types []reflect.Type
+		for i := 1; i < m.Type.NumIn(); i++ { // skip the receiver
+			types = append(types, m.Type.In(i))
+		}
+		for i := 0; i < m.Type.NumOut(); i++ {
+			types = append(types, m.Type.Out(i))
+		}
+		return types
+	}
+
+	safe := reflect.TypeFor[*SafeGenerator]()
+	gen := reflect.TypeFor[*Generator]()
+	for i := 0; i < gen.NumMethod(); i++ {
+		m := gen.Method(i)
+		sm, ok := safe.MethodByName(m.Name)
+		switch {
+		case !ok:
+			t.Errorf("SafeGenerator has no %s", m.Name)
+		case m.Name != "Clone" && !slices.Equal(signature(sm), signature(m)):
+			t.Errorf("SafeGenerator.%s is %s, Generator.%s %s", m.Name, sm.Type, m.Name, m.Type)
+		}
+	}
+}
+
+func TestSafeGeneratorSequence(t *testing.T) {
+	s, g := NewSafe(WithSeed(5)), WithSeed(5)
+	for i := 0; i < 100; i++ {
+		if x, y := s.Random(), g.Random(); x != y {
+			t.Fatalf("call %d: SafeGenerator %q, Generator %q", i, x, y)
+		}
+	}
+	if c := s.Clone(); c.State() != s.State() || c.Chrome() != s.Chrome() {
+		t.Error("Clone does not continue the sequence")
+	}
+}
+
+func TestSafeGeneratorConcurrent(t *testing.T) {
+	s := NewSafe(WithSeed(5))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if s.Chrome() == "" || s.RandomIdentity().UserAgent == "" {
+					t.Error("empty User-Agent")
+					return
+				}
+				if _, err := s.Generate(Query{Browsers: []Browser{BrowserFirefox}}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSafeGeneratorStreams(t *testing.T) {
+	run := func(s *SafeGenerator, concurrent bool) map[string][]string {
+		var mu sync.Mutex
+		results := map[string][]string{}
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			name := fmt.Sprintf("worker-%d", i)
+			work := func() {
+				g := s.Stream(name)
+				var uas []string
+				for j := 0; j < 50; j++ {
+					s.Random() // other callers advancing s must not matter
+					uas = append(uas, g.Random())
+				}
+				mu.Lock()
+				results[name] = uas
+				mu.Unlock()
+			}
+			if concurrent {
+				wg.Add(1)
+				go func() { defer wg.Done(); work() }()
+			} else {
+				work()
+			}
+		}
+		wg.Wait()
+		return results
+	}
+
+	want := run(NewSafe(WithSeed(5)), false)
+	got := run(NewSafe(WithSeed(5)), true)
+	if !reflect.DeepEqual(got, want) {
+		t.Error("streams differ between sequential and concurrent runs")
+	}
+	if slices.Equal(want["worker-0"], want["worker-1"]) {
+		t.Error("worker-0 and worker-1 share a stream")
+	}
+	if slices.Equal(want["worker-0"], run(NewSafe(WithSeed(6)), false)["worker-0"]) {
+		t.Error("seeds 5 and 6 give worker-0 the same stream")
+	}
+}
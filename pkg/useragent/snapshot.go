@@ -0,0 +1,80 @@
+package ua
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Snapshots are past versions of the compiled-in data, kept in the module
+// under snapshots/ and named by the date they were generated. Every data
+// update adds one, so a seed keeps producing the same User-Agents after
+// upgrading the library:
+//
+//	g := ua.WithSeed(12345, ua.MustSnapshot("2026-01-18"))
+//
+// A seed and a snapshot ID produce the same sequence in every release.
+// Snapshots carry every table, so they never fall back to the
+// compiled-in data.
+// Snapshots are never edited or removed; rendering changes that would
+// alter their output land behind options.
+
+//go:embed snapshots/*.json
+var snapshotFiles embed.FS
+
+// ErrUnknownSnapshot is returned by Snapshot for IDs not in Snapshots
+var ErrUnknownSnapshot = errors.New("ua: unknown dataset snapshot")
+
+// Snapshots returns the IDs of the snapshots kept in the module, oldest first
+func Snapshots() []string {
+	entries, _ := snapshotFiles.ReadDir("snapshots") // embedded, cannot fail
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Snapshot returns the snapshot with the given ID. The error wraps
+// ErrUnknownSnapshot if the module has none by that name.
+func Snapshot(id string) (*Dataset, error) {
+	raw, err := snapshotFiles.ReadFile(path.Join("snapshots", id+".json"))
+	if err != nil || strings.ContainsAny(id, "/.") {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSnapshot, id)
+	}
+	d, err := ReadDataset(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	if table := d.missingTable(); table != "" {
+		return nil, fmt.Errorf("ua: snapshot %s has no %s table", id, table)
+	}
+	d.ID = id
+	return d, nil
+}
+
+// WithSnapshot makes the Generator sample from the snapshot with the
+// given ID, like WithDataset. The error wraps ErrUnknownSnapshot if the
+// module has no such snapshot.
+func WithSnapshot(id string) (Option, error) {
+	d, err := Snapshot(id)
+	if err != nil {
+		return nil, err
+	}
+	return WithDataset(d), nil
+}
+
+// MustSnapshot is like WithSnapshot but panics on an unknown ID, so a
+// misspelt ID fails where the Option is created
+func MustSnapshot(id string) Option {
+	opt, err := WithSnapshot(id)
+	if err != nil {
+		panic(err)
+	}
+	return opt
+}
@@ -0,0 +1,145 @@
+package ua
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "write golden files for new snapshots")
+
+// snapshotOutput renders the golden output of WithSeed(12345) on snapshot id
+func snapshotOutput(t *testing.T, id string) string {
+	t.Helper()
+	g := WithSeed(12345, MustSnapshot(id))
+	var b strings.Builder
+	for i := 0; i < 100; i++ {
+		b.WriteString(g.Random())
+		b.WriteByte('\n')
+	}
+	for _, q := range []Query{{OS: []OS{OSAndroid}}, {Browsers: []Browser{BrowserChrome}, MaxMajor: 140}} {
+		for i := 0; i < 10; i++ {
+			id, err := g.Generate(q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.WriteString(id.UserAgent)
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// Golden output of WithSeed(12345) for every snapshot. A failure means a
+// change broke the promise that a seed and snapshot ID reproduce the same
+// User-Agents across releases; -update is only for new snapshots.
+func TestSnapshotGolden(t *testing.T) {
+	for _, id := range Snapshots() {
+		t.Run(id, func(t *testing.T) {
+			out := snapshotOutput(t, id)
+			golden := filepath.Join("testdata", "snapshot-"+id+".golden")
+			if _, err := os.Stat(golden); *update && os.IsNotExist(err) {
+				if err := os.WriteFile(golden, []byte(out), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run go test -update for a new snapshot)", err)
+			}
+			if out != string(want) {
+				t.Errorf("seed 12345 on snapshot %s no longer matches %s", id, golden)
+			}
+		})
+	}
+}
+
+// Snapshots must not depend on the compiled-in data, which changes with
+// every data update
+func TestSnapshotSelfContained(t *testing.T) {
+	want := map[string]string{}
+	for _, id := range Snapshots() {
+		want[id] = snapshotOutput(t, id)
+	}
+
+	other := &Dataset{
+		Chrome:         []VersionWeight{{"120.0.0.0", 1}},
+		Firefox:        []VersionWeight{{"120.0", 1}},
+		Safari:         []VersionWeight{{"17.0", 1}},
+		Edge:           []VersionWeight{{"120.0.0.0", 1}},
+		Samsung:        []VersionWeight{{"23.0", 1}},
+		Windows:        []VersionWeight{{"6.1", 1}},
+		MacOS:          []VersionWeight{{"13_0", 1}},
+		Linux:          []VersionWeight{{"X11; Linux aarch64", 1}},
+		IOS:            []VersionWeight{{"17_0", 1}},
+		Android:        []VersionWeight{{"13", 1}},
+		AndroidDevices: []DeviceWeight{{"Pixel 7", "TQ3A.230805.001", 1}},
+	}
+	saved := defaultTables
+	defaultTables = other.compile()
+	SetDataset(other)
+	defer func() {
+		defaultTables = saved
+		SetDataset(nil)
+	}()
+
+	for id, out := range want {
+		if snapshotOutput(t, id) != out {
+			t.Errorf("snapshot %s depends on the default data", id)
+		}
+	}
+}
+
+func TestSnapshotIncomplete(t *testing.T) {
+	d, err := Snapshot(Snapshots()[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table := d.missingTable(); table != "" {
+		t.Errorf("snapshot %s has no %s table", d.ID, table)
+	}
+	d.Samsung = []VersionWeight{{"25.0", 0}}
+	if table := d.missingTable(); table != "samsung" {
+		t.Errorf("missingTable() = %q, want samsung", table)
+	}
+}
+
+func TestSnapshotMatchesDefault(t *testing.T) {
+	ids := Snapshots()
+	if len(ids) == 0 {
+		t.Fatal("no snapshots embedded")
+	}
+	// The newest snapshot is the compiled-in data
+	d, err := Snapshot(ids[len(ids)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := WithSeed(7), WithSeed(7, WithDataset(d))
+	for i := 0; i < 1000; i++ {
+		if x, y := a.Random(), b.Random(); x != y {
+			t.Fatalf("iteration %d: default %q, snapshot %s %q", i, x, d.ID, y)
+		}
+	}
+}
+
+func TestSnapshotUnknown(t *testing.T) {
+	for _, id := range []string{"1999-01-01", "../data", ""} {
+		if _, err := Snapshot(id); !errors.Is(err, ErrUnknownSnapshot) {
+			t.Errorf("Snapshot(%q) error = %v", id, err)
+		}
+	}
+
+	if _, err := WithSnapshot("1999-01-01"); !errors.Is(err, ErrUnknownSnapshot) {
+		t.Errorf("WithSnapshot error = %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustSnapshot accepted an unknown ID")
+		}
+	}()
+	MustSnapshot("1999-01-01")
+}
@@ -0,0 +1,154 @@
+package ua
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultStickyKeys is the number of keys a Sticky keeps when MaxKeys is 0
+const defaultStickyKeys = 1024
+
+// Sticky keeps one identity per key, such as a host, account or proxy, so
+// repeated requests look like the same browser and keep their session.
+// An identity is replaced by a fresh one once it is older than TTL or has
+// been handed out MaxUses times, and the least recently used keys are
+// forgotten beyond MaxKeys.
+//
+// A Sticky is safe for concurrent use. The zero value draws from a
+// time-seeded Generator and keeps identities until evicted; NewSticky
+// draws from a given one. Set Transport.Sticky to use it per host:
+//
+//	s := ua.NewSticky(ua.New())
+//	s.TTL = 30 * time.Minute
+//	client := &http.Client{Transport: &ua.Transport{Sticky: s}}
+type Sticky struct {
+	// TTL is how long an identity is kept, forever when 0
+	TTL time.Duration
+
+	// MaxUses is how many times an identity is handed out, unlimited when 0
+	MaxUses int
+
+	// MaxKeys bounds the keys kept, 1024 when 0
+	MaxKeys int
+
+	// Draw generates new identities, RandomDesktopIdentity when nil. It
+	// is called with the Sticky's Generator, one call at a time.
+	Draw func(*Generator) Identity
+
+	mu   sync.Mutex
+	gen  *Generator
+	keys map[string]*list.Element // of *stickyEntry
+	lru  list.List                // most recently used first
+	now  func() time.Time         // time.Now when nil
+}
+
+type stickyEntry struct {
+	key     string
+	id      Identity
+	profile Profile
+	created time.Time
+	uses    int
+}
+
+// NewSticky returns a Sticky drawing identities from g, which must not be
+// used elsewhere afterwards
+func NewSticky(g *Generator) *Sticky {
+	return &Sticky{gen: g}
+}
+
+// Identity returns the identity kept for key, drawing a new one if there
+// is none or it expired
+func (s *Sticky) Identity(key string) Identity {
+	id, _ := s.get(key)
+	return id
+}
+
+// Profile returns the profile of the identity kept for key, with the same
+// languages every time. Bots get a Profile with only the User-Agent set.
+func (s *Sticky) Profile(key string) Profile {
+	_, p := s.get(key)
+	return p
+}
+
+// Forget drops the identity kept for key
+func (s *Sticky) Forget(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.keys[key]; ok {
+		s.lru.Remove(e)
+		delete(s.keys, key)
+	}
+}
+
+// Len returns the number of keys kept, expired ones included
+func (s *Sticky) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.keys)
+}
+
+// get hands out the identity and profile kept for key, counting the use
+func (s *Sticky) get(key string) (Identity, Profile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	t := now()
+
+	if e, ok := s.keys[key]; ok {
+		se := e.Value.(*stickyEntry)
+		if !s.expired(se, t) {
+			se.uses++
+			s.lru.MoveToFront(e)
+			return se.id, se.profile.clone()
+		}
+		s.lru.Remove(e)
+		delete(s.keys, key)
+	}
+
+	if s.gen == nil {
+		s.gen = New()
+	}
+	if s.keys == nil {
+		s.keys = make(map[string]*list.Element)
+	}
+	id := drawIdentity(s.gen, s.Draw)
+	se := &stickyEntry{key: key, id: id, created: t, uses: 1}
+	if id.Bot != "" {
+		se.profile = Profile{UserAgent: id.UserAgent}
+	} else {
+		se.profile = s.gen.newProfile(id)
+	}
+	s.keys[key] = s.lru.PushFront(se)
+
+	limit := s.MaxKeys
+	if limit <= 0 {
+		limit = defaultStickyKeys
+	}
+	for len(s.keys) > limit {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.keys, oldest.Value.(*stickyEntry).key)
+	}
+	return se.id, se.profile.clone()
+}
+
+// clone returns a copy of p that callers can change without affecting
+// the profile kept for later requests
+func (p Profile) clone() Profile {
+	p.Languages = slices.Clone(p.Languages)
+	if p.Hints != nil {
+		h := *p.Hints
+		p.Hints = &h
+	}
+	return p
+}
+
+func (s *Sticky) expired(se *stickyEntry, now time.Time) bool {
+	return s.TTL > 0 && now.Sub(se.created) >= s.TTL ||
+		s.MaxUses > 0 && se.uses >= s.MaxUses
+}
@@ -0,0 +1,102 @@
+package ua
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSticky(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSticky(WithSeed(1))
+	s.now = func() time.Time { return now }
+	s.TTL = time.Hour
+
+	a := s.Profile("a.example")
+	for i := 0; i < 10; i++ {
+		if p := s.Profile("a.example"); p.UserAgent != a.UserAgent || p.acceptLanguage() != a.acceptLanguage() {
+			t.Fatalf("identity for a.example changed from %q to %q", a.UserAgent, p.UserAgent)
+		}
+	}
+	if s.Identity("b.example") == s.Identity("a.example") {
+		t.Error("a.example and b.example share an identity")
+	}
+
+	now = now.Add(time.Hour)
+	if id := s.Identity("a.example"); id.UserAgent == a.UserAgent {
+		t.Errorf("identity kept past its TTL")
+	}
+}
+
+func TestStickyProfileCopies(t *testing.T) {
+	s := NewSticky(WithSeed(1))
+	s.Draw = (*Generator).ChromeIdentity
+
+	p := s.Profile("a.example")
+	if len(p.Languages) == 0 || p.Hints == nil {
+		t.Fatalf("Chrome profile without languages or hints: %+v", p)
+	}
+	lang, platform := p.Languages[0], p.Hints.Platform
+	p.Languages[0] = "xx"
+	p.Hints.Platform = `"Plan 9"`
+
+	if q := s.Profile("a.example"); q.Languages[0] != lang || q.Hints.Platform != platform {
+		t.Errorf("changing a returned profile changed the kept one: %v %s", q.Languages, q.Hints.Platform)
+	}
+}
+
+func TestStickyMaxUses(t *testing.T) {
+	s := NewSticky(WithSeed(1))
+	s.MaxUses = 3
+	s.Draw = (*Generator).ChromeIdentity
+
+	first := s.Identity("k")
+	for i := 1; i < 3; i++ {
+		if id := s.Identity("k"); id != first {
+			t.Fatalf("use %d got a new identity", i+1)
+		}
+	}
+	if id := s.Identity("k"); id == first {
+		t.Error("identity kept past MaxUses")
+	}
+}
+
+func TestStickyLRU(t *testing.T) {
+	s := NewSticky(WithSeed(1))
+	s.MaxKeys = 2
+
+	a := s.Identity("a")
+	s.Identity("b")
+	s.Identity("a") // b is now the least recently used
+	s.Identity("c")
+	if s.Len() != 2 {
+		t.Fatalf("kept %d keys, want 2", s.Len())
+	}
+	if id := s.Identity("a"); id != a {
+		t.Error("recently used key evicted")
+	}
+
+	s.Forget("a")
+	if s.Len() != 1 {
+		t.Errorf("kept %d keys after Forget, want 1", s.Len())
+	}
+}
+
+func TestTransportSticky(t *testing.T) {
+	tr := &Transport{Sticky: NewSticky(WithSeed(1))}
+	get := func(url string) string {
+		req, _ := http.NewRequest("GET", url, nil)
+		return sent(t, tr, req).Header.Get("User-Agent")
+	}
+
+	a := get("https://a.example/one")
+	if got := get("https://a.example/two"); got != a {
+		t.Errorf("second request to a.example sent %q, want %q", got, a)
+	}
+	if got := get("https://b.example/"); got == a {
+		t.Error("b.example sent the identity of a.example")
+	}
+	if got := tr.Sticky.Identity("a.example").UserAgent; got != a {
+		t.Errorf("Sticky keeps %q for a.example, the transport sent %q", got, a)
+	}
+}
@@ -0,0 +1,182 @@
+package ua
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Samsung Internet version (not tracked by the usage data)
+const samsungBrowserVersion = "25.0"
+
+// tables are the distributions a Generator samples from
+type tables struct {
+	chrome, firefox, safari, edge, samsung weighted[string]
+	windows, mac, linux, ios, android      weighted[string]
+	androidDevices                         weighted[androidDevice]
+
+	// Joint tables, see Dataset.BrowserOS and Dataset.DeviceAndroid,
+	// and their alias tables built by linkJoint
+	browserOS       []BrowserOSWeight
+	deviceAndroid   []DeviceAndroidWeight
+	osByBrowser     map[jointKey]weighted[string]
+	androidByDevice map[string]weighted[string]
+
+	// OS releases each browser version runs on, see link
+	chromeOn, firefoxOn, safariOn, edgeOn, samsungOn map[string]*platforms
+
+	id        string // Dataset.ID
+	source    string
+	generated time.Time
+}
+
+// platforms are the OS releases a browser version runs on, restricted
+// by Compatible and the coherence rules
+type platforms struct {
+	windows, mac, ios, android weighted[string]
+	devices                    weighted[androidDevice]
+
+	macReleases weighted[string] // behind the frozen macOS token, empty if none
+
+	// Android versions by device model; nil without joint data
+	deviceAndroid map[string]weighted[string]
+}
+
+// defaultTables are built from the usage data in data.go
+var defaultTables = (&tables{
+	chrome:         chromeVersions,
+	firefox:        firefoxVersions,
+	safari:         safariVersions,
+	edge:           edgeVersions,
+	samsung:        newWeighted([]entry[string]{{samsungBrowserVersion, 1}}),
+	windows:        windowsVersions,
+	mac:            macVersions,
+	linux:          linuxDesktops,
+	ios:            iosVersions,
+	android:        androidVersions,
+	androidDevices: androidDevices,
+	browserOS:      browserOSVersions,
+	deviceAndroid:  deviceAndroidVersions,
+}).link()
+
+// link precomputes the OS releases each browser version runs on, so
+// generators pick compatible pairs in O(1)
+func (t *tables) link() *tables {
+	t.linkJoint()
+	t.chromeOn = t.platformsByVersion(BrowserChrome)
+	t.firefoxOn = t.platformsByVersion(BrowserFirefox)
+	t.safariOn = t.platformsByVersion(BrowserSafari)
+	t.edgeOn = t.platformsByVersion(BrowserEdge)
+	t.samsungOn = t.platformsByVersion(BrowserSamsung)
+	return t
+}
+
+func (t *tables) platformsByVersion(b Browser) map[string]*platforms {
+	versions := t.versions(b)
+	m := make(map[string]*platforms, len(versions.values))
+	for _, v := range versions.values {
+		m[v] = t.platformsFor(b, v)
+	}
+	return m
+}
+
+// platformsFor restricts the OS tables to the releases browser version
+// runs on, taken from the joint tables where they have any. A table left
+// empty, which the data pipeline prevents, falls back to what the browser
+// would report or to the unrestricted table.
+func (t *tables) platformsFor(b Browser, version string) *platforms {
+	rules, major := compatBrowser(b, version)
+	osCompatible := func(os OS) func(string) bool {
+		return func(v string) bool { return Compatible(rules, major, os, v) }
+	}
+
+	var p platforms
+	var ok bool
+	if p.windows, ok = t.osReleases(b, version, OSWindows, t.windows, osCompatible(OSWindows)); !ok {
+		p.windows = newWeighted([]entry[string]{{"10.0", 1}})
+	}
+	if p.mac, ok = t.osReleases(b, version, OSMacOS, t.mac, func(v string) bool { return macTokenCompatible(rules, major, v) }); !ok {
+		p.mac = newWeighted([]entry[string]{{macFallback(b, major), 1}})
+	}
+	p.macReleases, _ = macReleases.filter(func(v string) bool { return Compatible(rules, major, OSMacOS, dotted(v)) })
+	if b == BrowserSafari {
+		p.ios, ok = t.osReleases(b, version, OSIOS, t.ios, func(v string) bool { return safariOnIOS(version, v) })
+		if !ok { // iOS ships Safari with its own version number
+			p.ios = newWeighted([]entry[string]{{strings.ReplaceAll(version, ".", "_"), 1}})
+		}
+	} else if p.ios, ok = t.osReleases(b, version, OSIOS, t.ios, func(v string) bool { return Compatible(rules, major, OSIOS, dotted(v)) }); !ok {
+		p.ios = t.ios
+	}
+	if p.android, ok = t.osReleases(b, version, OSAndroid, t.android, osCompatible(OSAndroid)); !ok {
+		p.android = t.android
+	}
+	p.deviceAndroid = t.androidReleases(osCompatible(OSAndroid))
+	p.devices, ok = t.androidDevices.filter(func(d androidDevice) bool {
+		v, known := androidBuildVersion(d.build)
+		return !known || Compatible(rules, major, OSAndroid, v)
+	})
+	if !ok {
+		p.devices = t.androidDevices
+	}
+	return &p
+}
+
+// compatBrowser returns the browser and major whose Compatible rules apply
+// to version of b: Samsung Internet follows its Chromium release
+func compatBrowser(b Browser, version string) (Browser, int) {
+	if b == BrowserSamsung {
+		if v, ok := samsungChromiumVersions[majorVersion(version)]; ok {
+			b, version = BrowserChrome, v
+		}
+	}
+	major, _ := strconv.Atoi(majorVersion(version))
+	return b, major
+}
+
+// macFallback returns the macOS version (10_15_7 format) browser major
+// reports when the data has no compatible one
+func macFallback(b Browser, major int) string {
+	if b == BrowserSafari && major < 14 {
+		// Safari 8 shipped with OS X 10.10, Safari 13 with 10.15
+		return "10_" + strconv.Itoa(max(major+2, 10)) + "_0"
+	}
+	return macOSFrozen
+}
+
+// on returns the OS releases version of browser b runs on
+func (t *tables) on(b Browser, version string) *platforms {
+	var m map[string]*platforms
+	switch b {
+	case BrowserChrome, BrowserWebView:
+		m = t.chromeOn
+	case BrowserFirefox:
+		m = t.firefoxOn
+	case BrowserSafari:
+		m = t.safariOn
+	case BrowserEdge:
+		m = t.edgeOn
+	case BrowserSamsung:
+		m = t.samsungOn
+	}
+	if p, ok := m[version]; ok {
+		return p
+	}
+	return t.platformsFor(b, version)
+}
+
+// versions returns the table holding the versions of browser b
+func (t *tables) versions(b Browser) *weighted[string] {
+	switch b {
+	case BrowserChrome, BrowserWebView:
+		return &t.chrome
+	case BrowserFirefox:
+		return &t.firefox
+	case BrowserSafari:
+		return &t.safari
+	case BrowserEdge:
+		return &t.edge
+	case BrowserSamsung:
+		return &t.samsung
+	}
+	return nil
+}
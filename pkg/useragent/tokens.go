@@ -0,0 +1,252 @@
+package ua
+
+import "strings"
+
+// Platform tokens are rendered per browser family: the same OS release
+// reads "10_15_7" in Chromium and WebKit, "10.15" in Firefox, and each
+// family has its own Windows architecture and Linux distribution rules.
+
+// windowsArch is the CPU architecture of a Windows browser build
+type windowsArch int
+
+const (
+	archX64   windowsArch = iota // 64-bit build on x64
+	archWOW64                    // 32-bit build on 64-bit Windows
+	archARM64                    // native ARM64 build
+)
+
+// Share of Windows browser builds by architecture
+var windowsArchs = newWeighted([]entry[windowsArch]{
+	{archX64, 0.93},
+	{archARM64, 0.04},
+	{archWOW64, 0.03},
+})
+
+// windowsArchToken returns the architecture part of a Windows platform
+// token. ARM64 builds of Chrome, Edge and Firefox claim x64.
+func windowsArchToken(arch windowsArch) string {
+	if arch == archWOW64 {
+		return "WOW64"
+	}
+	return "Win64; x64"
+}
+
+// platformsOf returns the platforms the version of id was picked with.
+// Reduced Chromium versions would miss the precomputed tables.
+func (g *Generator) platformsOf(id *Identity) *platforms {
+	return g.t.on(id.Browser, id.version)
+}
+
+// Chromium
+
+// windowsChromium picks a Windows version for id and writes its platform token
+func (g *Generator) windowsChromium(id *Identity, b *strings.Builder) {
+	nt := g.platformsOf(id).windows.pick(g.rng)
+	arch := windowsArchs.pick(g.rng)
+	id.OS, id.OSVersion = OSWindows, nt
+	id.hints.platform = OSWindows
+	id.hints.platformVersion = g.windowsPlatformVersion(nt)
+	switch arch {
+	case archWOW64:
+		id.hints.arch, id.hints.bitness, id.hints.wow64 = "x86", "32", true
+	case archARM64:
+		id.hints.arch, id.hints.bitness = "arm", "64"
+	default:
+		id.hints.arch, id.hints.bitness = "x86", "64"
+	}
+
+	if g.reduces(id.hints.version, reducedDesktopSince) {
+		id.Reduced = true
+		b.WriteString(reducedWindows)
+		return
+	}
+	b.WriteString("Windows NT ")
+	b.WriteString(nt)
+	b.WriteString("; ")
+	b.WriteString(windowsArchToken(arch))
+}
+
+// macChromium picks a macOS version for id and writes its platform token
+func (g *Generator) macChromium(id *Identity, b *strings.Builder) {
+	macVer := g.macRelease(g.platformsOf(id), id.Browser, id.hints.version)
+	id.OS, id.OSVersion = OSMacOS, dotted(macVer)
+	id.hints.platform = OSMacOS
+	id.hints.platformVersion = dottedVersion(macVer)
+	id.hints.arch, id.hints.bitness = g.macArch(macVer), "64"
+
+	if g.reduces(id.hints.version, reducedDesktopSince) {
+		id.Reduced = true
+		b.WriteString(reducedMac)
+		return
+	}
+	token := macToken(id.Browser, majorNumber(id.hints.version), macVer)
+	id.Reduced = token != macVer
+	b.WriteString("Macintosh; Intel Mac OS X ")
+	b.WriteString(token)
+}
+
+// linuxChromium picks a Linux platform for id and writes its platform token.
+// Chromium never sends the distribution some Linux builds of Firefox add.
+func (g *Generator) linuxChromium(id *Identity, b *strings.Builder) {
+	desktop := g.t.linux.pick(g.rng)
+	id.OS = OSLinux
+	id.hints.platform = OSLinux
+	id.hints.platformVersion = linuxKernelVersions.pick(g.rng)
+	id.hints.arch, id.hints.bitness = linuxArch(desktop)
+
+	if g.reduces(id.hints.version, reducedDesktopSince) {
+		id.Reduced = true
+		b.WriteString(reducedLinux)
+		return
+	}
+	b.WriteString("X11; Linux ")
+	b.WriteString(linuxCPU(desktop))
+}
+
+// chromeOSChromium picks a ChromeOS version for id and writes its platform
+// token, frozen by User-Agent reduction since Chrome 107
+func (g *Generator) chromeOSChromium(id *Identity, b *strings.Builder) {
+	version := chromeOSPlatformVersions.pick(g.rng)
+	id.OS, id.OSVersion = OSChromeOS, version
+	id.hints.platform = OSChromeOS
+	id.hints.platformVersion = version
+	id.hints.arch, id.hints.bitness = "x86", "64"
+
+	if g.reduces(id.hints.version, reducedDesktopSince) {
+		id.Reduced = true
+		version = chromeOSFrozenVersion
+	}
+	b.WriteString("X11; CrOS x86_64 ")
+	b.WriteString(version)
+}
+
+// chromiumPlatform picks an os release for id and writes its platform token
+func (g *Generator) chromiumPlatform(os OS, id *Identity, b *strings.Builder) {
+	switch os {
+	case OSWindows:
+		g.windowsChromium(id, b)
+	case OSMacOS:
+		g.macChromium(id, b)
+	case OSChromeOS:
+		g.chromeOSChromium(id, b)
+	default:
+		g.linuxChromium(id, b)
+	}
+}
+
+// androidChromium writes the Android platform token of a Chrome or Edge
+// User-Agent for id, frozen by UA reduction since Chrome 110
+func (g *Generator) androidChromium(id *Identity, b *strings.Builder) {
+	if g.reduces(id.hints.version, reducedAndroidSince) {
+		id.Reduced = true
+		b.WriteString(reducedAndroid)
+		return
+	}
+
+	b.WriteString("Linux; Android ")
+	b.WriteString(id.OSVersion)
+	b.WriteString("; ")
+	b.WriteString(id.DeviceModel)
+	b.WriteString(" Build/")
+	b.WriteString(id.DeviceBuild)
+}
+
+// linuxCPU returns the CPU part of a Linux platform token, e.g. "x86_64"
+// from "X11; Ubuntu; Linux x86_64"
+func linuxCPU(platform string) string {
+	return platform[strings.LastIndexByte(platform, ' ')+1:]
+}
+
+// Firefox
+
+// Firefox 110-119 kept rv: at 109.0 so sites parsing a two-digit
+// version kept working; 120 went back to the real version
+const (
+	firefoxFrozenRVSince = 110
+	firefoxFrozenRVUntil = 119
+	firefoxFrozenRV      = "109.0"
+)
+
+// firefoxRV returns the rv: value Firefox version sends
+func firefoxRV(version string) string {
+	if major := majorNumber(version); major >= firefoxFrozenRVSince && major <= firefoxFrozenRVUntil {
+		return firefoxFrozenRV
+	}
+	return version
+}
+
+// windowsFirefox picks a Windows version for id and writes its platform token
+func (g *Generator) windowsFirefox(id *Identity, b *strings.Builder) {
+	nt := g.platformsOf(id).windows.pick(g.rng)
+	id.OS, id.OSVersion = OSWindows, nt
+
+	b.WriteString("Windows NT ")
+	b.WriteString(nt)
+	b.WriteString("; ")
+	b.WriteString(windowsArchToken(windowsArchs.pick(g.rng)))
+}
+
+// macFirefox picks a macOS version for id and writes its platform token.
+// Firefox sends the release as major.minor with dots, e.g. "10.14".
+func (g *Generator) macFirefox(id *Identity, b *strings.Builder) {
+	macVer := g.macRelease(g.platformsOf(id), id.Browser, id.BrowserVersion)
+	id.OS, id.OSVersion = OSMacOS, dotted(macVer)
+
+	b.WriteString("Macintosh; Intel Mac OS X ")
+	if !g.legacy && majorNumber(id.BrowserVersion) >= firefoxFrozenMacSince &&
+		compareVersions(id.OSVersion, firefoxFrozenMac) >= 0 {
+		id.Reduced = id.OSVersion != firefoxFrozenMac
+		b.WriteString(firefoxFrozenMac)
+		return
+	}
+	token := majorMinor(macVer, "_")
+	if ord, ok := macOrdinal(macVer); ok && ord >= 6 {
+		token = firefoxCompatMac
+	}
+	id.Reduced = token != id.OSVersion
+	b.WriteString(token)
+}
+
+// linuxFirefox picks a Linux platform for id and writes its platform token
+func (g *Generator) linuxFirefox(id *Identity, b *strings.Builder) {
+	id.OS = OSLinux
+	b.WriteString(g.t.linux.pick(g.rng))
+}
+
+// firefoxPlatform picks an os release for id and writes its platform token
+func (g *Generator) firefoxPlatform(os OS, id *Identity, b *strings.Builder) {
+	switch os {
+	case OSWindows:
+		g.windowsFirefox(id, b)
+	case OSMacOS:
+		g.macFirefox(id, b)
+	default:
+		g.linuxFirefox(id, b)
+	}
+}
+
+// WebKit
+
+// macWebKit picks a macOS version for id and writes its platform token.
+// Safari 14+ reports macOS 11 and later as 10_15_7.
+func (g *Generator) macWebKit(id *Identity, b *strings.Builder) {
+	macVer := g.macRelease(g.platformsOf(id), id.Browser, id.BrowserVersion)
+	token := macToken(id.Browser, majorNumber(id.BrowserVersion), macVer)
+	id.OS, id.OSVersion = OSMacOS, dotted(macVer)
+	id.Reduced = token != macVer
+
+	b.WriteString("Macintosh; Intel Mac OS X ")
+	b.WriteString(token)
+}
+
+// iosWebKit writes the platform token of an iOS browser on id's device,
+// e.g. "iPhone; CPU iPhone OS 17_4_1 like Mac OS X"
+func iosWebKit(id *Identity, iosVer string, b *strings.Builder) {
+	if id.DeviceModel == "iPad" {
+		b.WriteString("iPad; CPU OS ")
+	} else {
+		b.WriteString("iPhone; CPU iPhone OS ")
+	}
+	b.WriteString(iosVer)
+	b.WriteString(" like Mac OS X")
+}
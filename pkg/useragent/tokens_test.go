@@ -0,0 +1,89 @@
+package ua
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFirefoxMacDotted(t *testing.T) {
+	g := WithSeed(8, WithLegacyUA())
+	re := regexp.MustCompile(`\(Macintosh; Intel Mac OS X \d+\.\d+; rv:`)
+
+	for i := 0; i < 500; i++ {
+		if ua := g.FirefoxMac(); !re.MatchString(ua) {
+			t.Fatalf("Firefox macOS token not major.minor: %s", ua)
+		}
+	}
+}
+
+func TestWebKitMacUnderscored(t *testing.T) {
+	g := WithSeed(8, WithLegacyUA())
+	re := regexp.MustCompile(`\(Macintosh; Intel Mac OS X \d+(_\d+)*\)`)
+
+	for i := 0; i < 500; i++ {
+		for _, ua := range []string{g.Safari(), g.ChromeMac()} {
+			if !re.MatchString(ua) {
+				t.Fatalf("macOS token not underscored: %s", ua)
+			}
+		}
+	}
+}
+
+func TestChromiumLinuxWithoutDistro(t *testing.T) {
+	g := WithSeed(8, WithLegacyUA())
+	re := regexp.MustCompile(`\(X11; Linux (x86_64|aarch64|i686)\)`)
+
+	for i := 0; i < 500; i++ {
+		if ua := g.ChromeLinux(); !re.MatchString(ua) {
+			t.Fatalf("Chromium Linux token with distribution: %s", ua)
+		}
+	}
+}
+
+func TestWindowsArchTokens(t *testing.T) {
+	g := WithSeed(8, WithLegacyUA())
+	seen := map[string]bool{}
+
+	for i := 0; i < 2000; i++ {
+		id := g.ChromeWindowsIdentity()
+		hints, _ := id.ClientHints()
+		he := hints.HighEntropy()
+		wow64 := strings.Contains(id.UserAgent, "; WOW64)")
+		switch {
+		case wow64 && (he.WoW64 != "?1" || he.Bitness != `"32"`):
+			t.Fatalf("WOW64 UA with WoW64 %s, bitness %s: %s", he.WoW64, he.Bitness, id.UserAgent)
+		case !wow64 && (he.WoW64 != "?0" || !strings.Contains(id.UserAgent, "; Win64; x64)")):
+			t.Fatalf("64-bit UA with WoW64 %s: %s", he.WoW64, id.UserAgent)
+		}
+		seen[he.Arch+"/"+he.Bitness] = true
+	}
+	for _, arch := range []string{`"x86"/"64"`, `"x86"/"32"`, `"arm"/"64"`} {
+		if !seen[arch] {
+			t.Errorf("no Windows build with arch/bitness %s", arch)
+		}
+	}
+
+	for i := 0; i < 500; i++ {
+		if ua := g.FirefoxWindows(); !strings.Contains(ua, "; Win64; x64; rv:") && !strings.Contains(ua, "; WOW64; rv:") {
+			t.Fatalf("Firefox Windows token without architecture: %s", ua)
+		}
+	}
+}
+
+func TestFirefoxRV(t *testing.T) {
+	tests := []struct{ version, rv string }{
+		{"109.0", "109.0"},
+		{"110.0", "109.0"},
+		{"115.0", "109.0"},
+		{"119.0", "109.0"},
+		{"120.0", "120.0"},
+		{"146.0", "146.0"},
+		{"78.0", "78.0"},
+	}
+	for _, tt := range tests {
+		if got := firefoxRV(tt.version); got != tt.rv {
+			t.Errorf("firefoxRV(%q) = %q, want %q", tt.version, got, tt.rv)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package ua
+
+import (
+	"net/http"
+	"sync"
+)
+
+// Transport is an http.RoundTripper that sends each request with a newly
+// generated identity, or the one Sticky keeps for the request's host: its
+// User-Agent together with the client hints and headers the browser sends
+// alongside it (see Profile). Headers already set on the request are kept,
+// and requests that carry a User-Agent are passed through untouched so the
+// generated headers never contradict it.
+//
+// A Transport is safe for concurrent use. The zero value draws from a
+// time-seeded Generator; NewTransport draws from a given one.
+//
+//	client := &http.Client{Transport: ua.NewTransport(ua.WithSeed(1))}
+type Transport struct {
+	// Base sends the requests, http.DefaultTransport when nil
+	Base http.RoundTripper
+
+	// Draw generates the identity of each request, RandomDesktopIdentity
+	// when nil. It is called with the Transport's Generator, one call at
+	// a time.
+	Draw func(*Generator) Identity
+
+	// Kind selects the Accept and Sec-Fetch-* headers, RequestDocument
+	// (a navigation) by default
+	Kind RequestKind
+
+	// Sticky, when set, keeps an identity per key instead of drawing one
+	// for every request; Draw is not used then
+	Sticky *Sticky
+
+	// Key returns the Sticky key of a request, its URL host when nil
+	Key func(*http.Request) string
+
+	mu  sync.Mutex
+	gen *Generator
+}
+
+// NewTransport returns a Transport drawing identities from g, which must
+// not be used elsewhere afterwards
+func NewTransport(g *Generator) *Transport {
+	return &Transport{gen: g}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Header["User-Agent"]; ok {
+		return t.base().RoundTrip(req)
+	}
+
+	h := t.header(req)
+	r := req.Clone(req.Context())
+	if r.Header == nil {
+		r.Header = make(http.Header, len(h))
+	}
+	for k, v := range h {
+		if _, ok := r.Header[k]; !ok {
+			r.Header[k] = v
+		}
+	}
+	return t.base().RoundTrip(r)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// header returns the headers of the identity req is sent with
+func (t *Transport) header(req *http.Request) http.Header {
+	var id Identity
+	var p Profile
+	if t.Sticky != nil {
+		key := req.URL.Host
+		if t.Key != nil {
+			key = t.Key(req)
+		}
+		id, p = t.Sticky.get(key)
+	} else {
+		id, p = t.draw()
+	}
+	if id.Bot != "" {
+		return http.Header{"User-Agent": {id.UserAgent}}
+	}
+	return p.transportHeader(t.Kind)
+}
+
+// draw generates the identity and profile of the next request
+func (t *Transport) draw() (Identity, Profile) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.gen == nil {
+		t.gen = New()
+	}
+	id := drawIdentity(t.gen, t.Draw)
+	if id.Bot != "" {
+		return id, Profile{}
+	}
+	return id, t.gen.newProfile(id)
+}
+
+// drawIdentity draws an identity with f, RandomDesktopIdentity when nil
+func drawIdentity(g *Generator, f func(*Generator) Identity) Identity {
+	if f == nil {
+		return g.RandomDesktopIdentity()
+	}
+	return f(g)
+}
+
+// transportHeader returns the headers of kind for a request sent through
+// Transport. Accept-Encoding is left to the base transport: setting it
+// turns off the transparent gzip decoding of http.Transport, and callers
+// would get brotli or zstd bodies they cannot read.
+func (p Profile) transportHeader(kind RequestKind) http.Header {
+	h := p.Header(kind)
+	h.Del("Accept-Encoding")
+	return h
+}
@@ -0,0 +1,96 @@
+package ua
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// roundTripFunc records the requests a Transport sends
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func sent(t *testing.T, tr *Transport, req *http.Request) *http.Request {
+	t.Helper()
+	var got *http.Request
+	tr.Base = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		got = r
+		return &http.Response{StatusCode: http.StatusOK, Request: r}, nil
+	})
+	if _, err := tr.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestTransport(t *testing.T) {
+	tr := NewTransport(WithSeed(1))
+	tr.Draw = (*Generator).ChromeIdentity
+
+	req, _ := http.NewRequest("GET", "https://example.com/", nil)
+	req.Header.Set("Accept-Language", "nl")
+	r := sent(t, tr, req)
+
+	if u := Parse(r.Header.Get("User-Agent")); u.Browser != BrowserChrome {
+		t.Errorf("User-Agent %q, want Chrome", r.Header.Get("User-Agent"))
+	}
+	for _, h := range []string{"Sec-CH-UA", "Sec-CH-UA-Platform", "Sec-Fetch-Mode", "Accept"} {
+		if r.Header.Get(h) == "" {
+			t.Errorf("no %s header", h)
+		}
+	}
+	if got := r.Header.Get("Accept-Language"); got != "nl" {
+		t.Errorf("Accept-Language %q, want the caller's", got)
+	}
+	if got := r.Header.Get("Accept-Encoding"); got != "" {
+		t.Errorf("Accept-Encoding %q set, disabling transparent decoding", got)
+	}
+	if len(req.Header) != 1 {
+		t.Errorf("original request modified: %v", req.Header)
+	}
+}
+
+func TestTransportKeepsUserAgent(t *testing.T) {
+	tr := &Transport{}
+	req, _ := http.NewRequest("GET", "https://example.com/", nil)
+	req.Header.Set("User-Agent", "my-crawler/1.0")
+	if r := sent(t, tr, req); r != req {
+		t.Errorf("request with a User-Agent rewritten to %v", r.Header)
+	}
+
+	tr.Draw = (*Generator).RandomBotIdentity
+	req, _ = http.NewRequest("GET", "https://example.com/", nil)
+	r := sent(t, tr, req)
+	if len(r.Header) != 1 || Parse(r.Header.Get("User-Agent")).Bot == "" {
+		t.Errorf("bot request with headers %v", r.Header)
+	}
+}
+
+func TestTransportConcurrent(t *testing.T) {
+	tr := NewTransport(WithSeed(2))
+	tr.Base = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Request: r}, nil
+	})
+	client := &http.Client{Transport: tr}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				resp, err := client.Get("https://example.com/")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if resp.Request.Header.Get("User-Agent") == "" {
+					t.Error("request sent without a User-Agent")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -20,53 +20,136 @@
 //	g := ua.WithSeed(12345)
 //	g.Chrome()  // same sequence every time
 //
-// Thread safety: Package-level functions are goroutine-safe.
-// Individual Generator instances are NOT goroutine-safe.
+// The sequence follows the compiled-in data, which changes with every
+// data update. Pin a snapshot to keep it across releases:
+//
+//	g := ua.WithSeed(12345, ua.MustSnapshot("2026-01-18"))
+//
+// Thread safety: Package-level functions are goroutine-safe and scale
+// with cores, each call drawing from a pooled generator. Seed trades that
+// for a reproducible sequence. Individual Generator instances are NOT
+// goroutine-safe; NewSafe wraps one in a SafeGenerator that is.
 package ua
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// Package-level functions draw from a pool of time-seeded generators, so
+// concurrent callers never wait on each other. Seed replaces the pool
+// with a single generator behind globalLock to make them reproducible.
 var (
-	globalGen  = newTimeSeeded()
+	globalPool = sync.Pool{New: func() any { return newPooled() }}
+	globalSeed = uint64(time.Now().UnixNano()) // namespaces ForKey in the pool
+	poolSeeds  atomic.Uint64                   // generators created by the pool
+
+	seeded     atomic.Bool
+	globalGen  *Generator // set by Seed
 	globalLock sync.Mutex
 )
 
 func newTimeSeeded() *Generator {
+	seed := uint64(time.Now().UnixNano())
 	return &Generator{
-		rng: newXorshift64(uint64(time.Now().UnixNano())),
+		rng:  newXorshift64(seed),
+		seed: seed,
+		t:    active.Load(),
 	}
 }
 
-// Seed sets a new seed for the global generator
+// newPooled creates a generator for globalPool with a seed of its own.
+// They share globalSeed so ForKey agrees whichever one serves the call.
+func newPooled() *Generator {
+	return &Generator{
+		rng:  newXorshift64(mix64(globalSeed + poolSeeds.Add(1))),
+		seed: globalSeed,
+		t:    active.Load(),
+	}
+}
+
+// Seed makes the package-level functions reproducible: they all draw
+// from one generator seeded with seed, the same sequence as WithSeed.
+// Concurrent calls then take turns on a lock; Unseed lifts it.
 // Useful for reproducible results in tests
 func Seed(seed uint64) {
 	globalLock.Lock()
 	globalGen = WithSeed(seed)
+	seeded.Store(true)
+	globalLock.Unlock()
+}
+
+// Unseed returns the package-level functions to independent time-seeded
+// generators after Seed
+func Unseed() {
+	globalLock.Lock()
+	globalGen = nil
+	seeded.Store(false)
 	globalLock.Unlock()
 }
 
+// acquire returns the generator for a package-level call and whether it
+// is the one set by Seed, held under globalLock. Pass both to release.
+func acquire() (g *Generator, locked bool) {
+	if seeded.Load() {
+		globalLock.Lock()
+		if globalGen != nil {
+			return globalGen, true
+		}
+		globalLock.Unlock() // Unseed ran meanwhile
+	}
+	return globalPool.Get().(*Generator), false
+}
+
+func release(g *Generator, locked bool) {
+	if locked {
+		globalLock.Unlock()
+		return
+	}
+	globalPool.Put(g)
+}
+
 // Generator generates random but reproducible User-Agent strings.
 // Individual Generator instances are NOT goroutine-safe.
-// For concurrent use, create separate generators per goroutine.
+// For concurrent use, create separate generators per goroutine
+// or wrap one with NewSafe.
 type Generator struct {
-	rng *xorshift64
+	rng    *xorshift64
+	seed   uint64 // as created, namespaces ForKey
+	t      *tables
+	pinned bool // t set by WithDataset, not replaced by SetDataset
+	mix    *mix // nil picks uniformly
+
+	legacy bool // render User-Agents without freezing, see WithLegacyUA
 }
 
+// Option configures a Generator created by New or WithSeed
+type Option func(*Generator)
+
 // New creates a new Generator with a time-based seed
 // For reproducible results, use WithSeed instead
-func New() *Generator {
-	return newTimeSeeded()
+func New(opts ...Option) *Generator {
+	return newTimeSeeded().apply(opts)
 }
 
 // WithSeed creates a new Generator with a specific seed
-// Same seed produces same sequence of User-Agents
-func WithSeed(seed uint64) *Generator {
-	return &Generator{
-		rng: newXorshift64(seed),
+// Same seed produces same sequence of User-Agents for the same data,
+// see WithSnapshot
+func WithSeed(seed uint64, opts ...Option) *Generator {
+	g := &Generator{
+		rng:  newXorshift64(seed),
+		seed: seed,
+		t:    active.Load(),
 	}
+	return g.apply(opts)
+}
+
+func (g *Generator) apply(opts []Option) *Generator {
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
 }
 
 // UAType represents a category of User-Agent
@@ -76,46 +159,76 @@ const (
 	TypeDesktop UAType = iota
 	TypeMobile
 	TypeBot
+	TypeTablet
+)
+
+// String returns the lowercase category name
+func (t UAType) String() string {
+	switch t {
+	case TypeDesktop:
+		return "desktop"
+	case TypeMobile:
+		return "mobile"
+	case TypeBot:
+		return "bot"
+	case TypeTablet:
+		return "tablet"
+	}
+	return "unknown"
+}
+
+// Browser is a browser family
+type Browser string
+
+const (
+	BrowserChrome           Browser = "Chrome"
+	BrowserFirefox          Browser = "Firefox"
+	BrowserSafari           Browser = "Safari"
+	BrowserEdge             Browser = "Edge"
+	BrowserSamsung          Browser = "Samsung Internet"
+	BrowserWebView          Browser = "Android WebView"
+	BrowserOpera            Browser = "Opera"
+	BrowserYandex           Browser = "Yandex Browser"
+	BrowserInternetExplorer Browser = "Internet Explorer"
+)
+
+// Engine is a browser rendering engine
+type Engine string
+
+const (
+	EngineBlink    Engine = "Blink"
+	EngineGecko    Engine = "Gecko"
+	EngineWebKit   Engine = "WebKit"
+	EngineEdgeHTML Engine = "EdgeHTML"
+	EngineTrident  Engine = "Trident"
+)
+
+// OS is an operating system family.
+// Values match what Chromium reports in Sec-CH-UA-Platform.
+type OS string
+
+const (
+	OSWindows  OS = "Windows"
+	OSMacOS    OS = "macOS"
+	OSLinux    OS = "Linux"
+	OSChromeOS OS = "Chrome OS"
+	OSAndroid  OS = "Android"
+	OSIOS      OS = "iOS"
 )
 
 // Random returns a random User-Agent from any category
 func (g *Generator) Random() string {
-	switch g.rng.intn(3) {
-	case 0:
-		return g.RandomDesktop()
-	case 1:
-		return g.RandomMobile()
-	default:
-		return g.RandomBot()
-	}
+	return g.RandomIdentity().UserAgent
 }
 
 // RandomDesktop returns a random desktop browser User-Agent
 func (g *Generator) RandomDesktop() string {
-	switch g.rng.intn(4) {
-	case 0:
-		return g.Chrome()
-	case 1:
-		return g.Firefox()
-	case 2:
-		return g.Safari()
-	default:
-		return g.Edge()
-	}
+	return g.RandomDesktopIdentity().UserAgent
 }
 
 // RandomMobile returns a random mobile browser User-Agent
 func (g *Generator) RandomMobile() string {
-	switch g.rng.intn(4) {
-	case 0:
-		return g.SafariIOS()
-	case 1:
-		return g.ChromeAndroid()
-	case 2:
-		return g.ChromeIOS()
-	default:
-		return g.AndroidWebView()
-	}
+	return g.RandomMobileIdentity().UserAgent
 }
 
 // Package-level bot UA slice (zero allocation on access)
@@ -147,164 +260,437 @@ func (g *Generator) State() uint64 {
 // Useful for creating checkpoints
 func (g *Generator) Clone() *Generator {
 	return &Generator{
-		rng: &xorshift64{state: g.rng.state},
+		rng:    &xorshift64{state: g.rng.state},
+		seed:   g.seed,
+		t:      g.t,
+		pinned: g.pinned,
+		mix:    g.mix,
+
+		legacy: g.legacy,
 	}
 }
 
-// --- Package-level functions using global generator (thread-safe) ---
+// --- Package-level functions using pooled generators (thread-safe) ---
 
 // Chrome returns a random Chrome desktop User-Agent
 func Chrome() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.Chrome()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.Chrome()
 }
 
 // ChromeWindows returns a Chrome User-Agent for Windows
 func ChromeWindows() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.ChromeWindows()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeWindows()
 }
 
 // ChromeMac returns a Chrome User-Agent for macOS
 func ChromeMac() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.ChromeMac()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeMac()
 }
 
 // ChromeLinux returns a Chrome User-Agent for Linux
 func ChromeLinux() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.ChromeLinux()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeLinux()
 }
 
 // Firefox returns a random Firefox desktop User-Agent
 func Firefox() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.Firefox()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.Firefox()
 }
 
 // FirefoxWindows returns a Firefox User-Agent for Windows
 func FirefoxWindows() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.FirefoxWindows()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.FirefoxWindows()
 }
 
 // FirefoxMac returns a Firefox User-Agent for macOS
 func FirefoxMac() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.FirefoxMac()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.FirefoxMac()
 }
 
 // Safari returns a Safari desktop User-Agent (macOS)
 func Safari() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.Safari()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.Safari()
 }
 
 // Edge returns a random Edge desktop User-Agent
 func Edge() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.Edge()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.Edge()
 }
 
 // EdgeWindows returns an Edge User-Agent for Windows
 func EdgeWindows() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.EdgeWindows()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.EdgeWindows()
 }
 
 // SafariIOS returns a Safari User-Agent for iPhone
 func SafariIOS() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.SafariIOS()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SafariIOS()
 }
 
 // SafariIPad returns a Safari User-Agent for iPad
 func SafariIPad() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.SafariIPad()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SafariIPad()
 }
 
 // ChromeIOS returns a Chrome User-Agent for iOS
 func ChromeIOS() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.ChromeIOS()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeIOS()
 }
 
 // ChromeAndroid returns a Chrome User-Agent for Android
 func ChromeAndroid() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.ChromeAndroid()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeAndroid()
 }
 
 // AndroidWebView returns an Android WebView User-Agent
 func AndroidWebView() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.AndroidWebView()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.AndroidWebView()
 }
 
 // FirefoxAndroid returns a Firefox User-Agent for Android
 func FirefoxAndroid() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.FirefoxAndroid()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.FirefoxAndroid()
 }
 
 // SamsungBrowser returns a Samsung Internet Browser User-Agent
 func SamsungBrowser() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.SamsungBrowser()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SamsungBrowser()
 }
 
 // EdgeAndroid returns an Edge User-Agent for Android
 func EdgeAndroid() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.EdgeAndroid()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.EdgeAndroid()
+}
+
+// ChromeWithHints returns a Chrome desktop User-Agent with matching client hints
+func ChromeWithHints() (string, ClientHints) {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeWithHints()
+}
+
+// ChromeAndroidWithHints returns a Chrome Android User-Agent with matching client hints
+func ChromeAndroidWithHints() (string, ClientHints) {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeAndroidWithHints()
+}
+
+// EdgeWithHints returns an Edge desktop User-Agent with matching client hints
+func EdgeWithHints() (string, ClientHints) {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.EdgeWithHints()
+}
+
+// EdgeAndroidWithHints returns an Edge Android User-Agent with matching client hints
+func EdgeAndroidWithHints() (string, ClientHints) {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.EdgeAndroidWithHints()
+}
+
+// SamsungBrowserWithHints returns a Samsung Internet User-Agent with matching client hints
+func SamsungBrowserWithHints() (string, ClientHints) {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SamsungBrowserWithHints()
+}
+
+// ChromeProfile returns a Chrome desktop Profile
+func ChromeProfile() Profile {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeProfile()
+}
+
+// ChromeAndroidProfile returns a Chrome Android Profile
+func ChromeAndroidProfile() Profile {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeAndroidProfile()
+}
+
+// EdgeProfile returns an Edge desktop Profile
+func EdgeProfile() Profile {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.EdgeProfile()
+}
+
+// EdgeAndroidProfile returns an Edge Android Profile
+func EdgeAndroidProfile() Profile {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.EdgeAndroidProfile()
+}
+
+// SamsungBrowserProfile returns a Samsung Internet Profile
+func SamsungBrowserProfile() Profile {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SamsungBrowserProfile()
+}
+
+// FirefoxProfile returns a Firefox desktop Profile
+func FirefoxProfile() Profile {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.FirefoxProfile()
+}
+
+// FirefoxAndroidProfile returns a Firefox Android Profile
+func FirefoxAndroidProfile() Profile {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.FirefoxAndroidProfile()
+}
+
+// SafariProfile returns a Safari desktop Profile
+func SafariProfile() Profile {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SafariProfile()
+}
+
+// SafariIOSProfile returns a Safari iPhone Profile
+func SafariIOSProfile() Profile {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SafariIOSProfile()
+}
+
+// ChromeIdentity returns a Chrome desktop Identity
+func ChromeIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeIdentity()
+}
+
+// ChromeWindowsIdentity returns a Chrome Identity for Windows
+func ChromeWindowsIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeWindowsIdentity()
+}
+
+// ChromeMacIdentity returns a Chrome Identity for macOS
+func ChromeMacIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeMacIdentity()
+}
+
+// ChromeLinuxIdentity returns a Chrome Identity for Linux
+func ChromeLinuxIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeLinuxIdentity()
+}
+
+// FirefoxIdentity returns a Firefox desktop Identity
+func FirefoxIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.FirefoxIdentity()
+}
+
+// FirefoxWindowsIdentity returns a Firefox Identity for Windows
+func FirefoxWindowsIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.FirefoxWindowsIdentity()
+}
+
+// FirefoxMacIdentity returns a Firefox Identity for macOS
+func FirefoxMacIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.FirefoxMacIdentity()
+}
+
+// SafariIdentity returns a Safari desktop Identity
+func SafariIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SafariIdentity()
+}
+
+// EdgeIdentity returns an Edge desktop Identity
+func EdgeIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.EdgeIdentity()
+}
+
+// EdgeWindowsIdentity returns an Edge Identity for Windows
+func EdgeWindowsIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.EdgeWindowsIdentity()
+}
+
+// SafariIOSIdentity returns a Safari Identity for iPhone
+func SafariIOSIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SafariIOSIdentity()
+}
+
+// SafariIPadIdentity returns a Safari Identity for iPad
+func SafariIPadIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SafariIPadIdentity()
+}
+
+// ChromeIOSIdentity returns a Chrome Identity for iPhone
+func ChromeIOSIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeIOSIdentity()
+}
+
+// ChromeAndroidIdentity returns a Chrome Identity for Android
+func ChromeAndroidIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ChromeAndroidIdentity()
+}
+
+// AndroidWebViewIdentity returns an Android WebView Identity
+func AndroidWebViewIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.AndroidWebViewIdentity()
+}
+
+// FirefoxAndroidIdentity returns a Firefox Identity for Android
+func FirefoxAndroidIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.FirefoxAndroidIdentity()
+}
+
+// SamsungBrowserIdentity returns a Samsung Internet Identity
+func SamsungBrowserIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.SamsungBrowserIdentity()
+}
+
+// EdgeAndroidIdentity returns an Edge Identity for Android
+func EdgeAndroidIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.EdgeAndroidIdentity()
+}
+
+// RandomIdentity returns a random Identity from any category
+func RandomIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.RandomIdentity()
+}
+
+// RandomDesktopIdentity returns a random desktop browser Identity
+func RandomDesktopIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.RandomDesktopIdentity()
+}
+
+// RandomMobileIdentity returns a random mobile browser Identity
+func RandomMobileIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.RandomMobileIdentity()
+}
+
+// RandomBotIdentity returns a random bot Identity
+func RandomBotIdentity() Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.RandomBotIdentity()
+}
+
+// ForKey returns the Identity of key for the package-level functions,
+// stable within the process, and across runs once set with Seed
+func ForKey(key string) Identity {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.ForKey(key)
+}
+
+// Generate returns a random Identity satisfying q
+func Generate(q Query) (Identity, error) {
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.Generate(q)
 }
 
 // Random returns a random User-Agent from any category
 func Random() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.Random()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.Random()
 }
 
 // RandomDesktop returns a random desktop browser User-Agent
 func RandomDesktop() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.RandomDesktop()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.RandomDesktop()
 }
 
 // RandomMobile returns a random mobile browser User-Agent
 func RandomMobile() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.RandomMobile()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.RandomMobile()
 }
 
 // RandomBot returns a random bot User-Agent
 func RandomBot() string {
-	globalLock.Lock()
-	defer globalLock.Unlock()
-	return globalGen.RandomBot()
+	g, locked := acquire()
+	defer release(g, locked)
+	return g.RandomBot()
 }
 
 // useragentBufSize is the pre-allocated buffer size for UA string building.
@@ -139,6 +139,16 @@ func BenchmarkChromeParallel(b *testing.B) {
 	})
 }
 
+// BenchmarkGlobalChromeParallel measures the package-level functions,
+// which should scale with GOMAXPROCS like a generator per goroutine
+func BenchmarkGlobalChromeParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = Chrome()
+		}
+	})
+}
+
 func BenchmarkRandomParallel(b *testing.B) {
 	b.RunParallel(func(pb *testing.PB) {
 		g := WithSeed(42)
@@ -147,3 +157,19 @@ func BenchmarkRandomParallel(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkWeightedPick(b *testing.B) {
+	rng := newXorshift64(42)
+	b.ResetTimer()
+	for b.Loop() {
+		_ = chromeVersions.pick(rng)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	s := WithSeed(42).ChromeAndroid()
+	b.ResetTimer()
+	for b.Loop() {
+		_ = Parse(s)
+	}
+}
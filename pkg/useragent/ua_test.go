@@ -2,7 +2,9 @@ package ua
 
 import (
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -257,6 +259,7 @@ func TestRandomBot(t *testing.T) {
 }
 
 func TestGlobalSeed(t *testing.T) {
+	t.Cleanup(Unseed)
 	Seed(99999)
 	ua1 := Chrome()
 	ua2 := Chrome()
@@ -273,6 +276,48 @@ func TestGlobalSeed(t *testing.T) {
 	}
 }
 
+func TestGlobalSeedMatchesWithSeed(t *testing.T) {
+	t.Cleanup(Unseed)
+	Seed(7)
+	g := WithSeed(7)
+	for i := 0; i < 100; i++ {
+		if x, y := Random(), g.Random(); x != y {
+			t.Fatalf("call %d: Seed(7) gave %q, WithSeed(7) %q", i, x, y)
+		}
+	}
+	if ForKey("proxy-7") != g.ForKey("proxy-7") {
+		t.Error("ForKey after Seed(7) differs from WithSeed(7)")
+	}
+
+	Unseed()
+	if Chrome() == "" {
+		t.Error("no User-Agent after Unseed")
+	}
+}
+
+func TestGlobalConcurrent(t *testing.T) {
+	key := ForKey("account-1")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if Chrome() == "" || RandomIdentity().UserAgent == "" {
+					t.Error("empty User-Agent")
+					return
+				}
+				if ForKey("account-1") != key {
+					t.Error("ForKey changed between pooled generators")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func TestAllDesktopBrowsers(t *testing.T) {
 	g := WithSeed(42)
 	browsers := []struct {
@@ -362,3 +407,60 @@ func TestGeneratorState(t *testing.T) {
 		t.Error("State should have changed after generating UA")
 	}
 }
+
+func TestWeightedPick(t *testing.T) {
+	w := newWeighted([]entry[string]{
+		{"a", 0.7},
+		{"b", 0.2},
+		{"c", 0.1},
+		{"never", 0},
+	})
+	rng := newXorshift64(42)
+
+	counts := make(map[string]int)
+	n := 100000
+	for i := 0; i < n; i++ {
+		counts[w.pick(rng)]++
+	}
+
+	if counts["never"] != 0 {
+		t.Errorf("zero-weight value picked %d times", counts["never"])
+	}
+	for v, want := range map[string]float64{"a": 0.7, "b": 0.2, "c": 0.1} {
+		got := float64(counts[v]) / float64(n)
+		if got < want-0.01 || got > want+0.01 {
+			t.Errorf("share of %q = %.3f, want %.3f", v, got, want)
+		}
+	}
+}
+
+func TestWeightedPickAllZero(t *testing.T) {
+	w := newWeighted([]entry[string]{{"a", 0}, {"b", 0}})
+	rng := newXorshift64(42)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		seen[w.pick(rng)] = true
+	}
+	if !seen["a"] || !seen["b"] {
+		t.Errorf("all-zero weights should sample uniformly, saw %v", seen)
+	}
+}
+
+func TestChromeFavorsPopularVersions(t *testing.T) {
+	g := WithSeed(42)
+
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		ua := g.ChromeWindows()
+		counts[ua[strings.Index(ua, "Chrome/")+7:strings.LastIndex(ua, " Safari/")]]++
+	}
+
+	if !slices.Contains(chromeVersions.values, "114.0.0.0") {
+		t.Fatal("114.0.0.0 is not in the Chrome data")
+	}
+	if counts["114.0.0.0"] == 0 || counts["142.0.0.0"] <= counts["114.0.0.0"]*10 {
+		t.Errorf("popular version not favored: 142=%d, 114=%d",
+			counts["142.0.0.0"], counts["114.0.0.0"])
+	}
+}
@@ -0,0 +1,82 @@
+//go:build ignore
+
+// This script compares two versions of the usage data and prints a
+// Markdown changelog: versions and devices added or removed per table
+// and the entries whose share of their table moved.
+//
+// Run with: go run scripts/diff_data.go [-old OLD] [-new NEW]
+//
+// OLD and NEW are each a data.go file, a dataset JSON file (snapshots
+// included) or a git revision of either. By default the committed
+// data.go is compared with the working copy, as left by generate_data.go:
+//
+//	go run scripts/diff_data.go
+//	go run scripts/diff_data.go -old HEAD~5:pkg/useragent/data.go
+//	go run scripts/diff_data.go -old pkg/useragent/snapshots/2026-01-18.json -new dataset.json
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	ua "github.com/nzrsky/useragent-generator/pkg/useragent"
+	"github.com/nzrsky/useragent-generator/pkg/useragent/extract"
+)
+
+const dataFile = "pkg/useragent/data.go"
+
+func main() {
+	oldPath := flag.String("old", "HEAD:"+dataFile, "data.go or dataset JSON file, or a git revision of one, to compare from")
+	newPath := flag.String("new", dataFile, "data.go or dataset JSON file, or a git revision of one, to compare to")
+	flag.Parse()
+
+	old, err := load(*oldPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", *oldPath, err)
+		os.Exit(1)
+	}
+	next, err := load(*newPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", *newPath, err)
+		os.Exit(1)
+	}
+
+	if err := extract.WriteChangelog(os.Stdout, extract.Diff(old, next)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing changelog: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// load reads a dataset from a file, or from a git revision when no file
+// named arg exists
+func load(arg string) (*ua.Dataset, error) {
+	src, err := os.ReadFile(arg)
+	if os.IsNotExist(err) && strings.Contains(arg, ":") {
+		src, err = gitShow(arg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if filepath.Ext(arg) == ".go" {
+		return extract.ParseDataFile(src)
+	}
+	return ua.ReadDataset(bytes.NewReader(src))
+}
+
+// gitShow returns the contents of a file at a revision, e.g. HEAD~1:path
+func gitShow(object string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "show", object)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git show %s: %s", object, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
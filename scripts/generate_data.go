@@ -4,82 +4,104 @@
 // version components to update pkg/useragent/data.go
 //
 // Run with: go run scripts/generate_data.go
+//
+// To regenerate offline or from a reviewed snapshot, pass a local copy
+// of the dataset (.json or .json.gz, - for stdin):
+//
+//	go run scripts/generate_data.go -in user-agents.json.gz
+//
+// With -json the data is written as a dataset file that generators load
+// at runtime with ua.LoadDataset, leaving data.go untouched:
+//
+//	go run scripts/generate_data.go -json dataset.json
 package main
 
 import (
-	"compress/gzip"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
-	"regexp"
-	"sort"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
+
+	ua "github.com/nzrsky/useragent-generator/pkg/useragent"
+	"github.com/nzrsky/useragent-generator/pkg/useragent/extract"
 )
 
 const (
 	dataURL    = "https://raw.githubusercontent.com/intoli/user-agents/main/src/user-agents.json.gz"
+	sourceURL  = "https://github.com/intoli/user-agents"
 	outputFile = "pkg/useragent/data.go"
-	topN       = 20 // top N versions to keep per category
-)
 
-// UserAgent represents a single user-agent entry from Intoli dataset
-type UserAgent struct {
-	UserAgent string  `json:"userAgent"`
-	Weight    float64 `json:"weight"`
-}
-
-// VersionWeight holds version string and its cumulative weight
-type VersionWeight struct {
-	Version string
-	Weight  float64
-}
-
-// ExtractedData holds all extracted version data
-type ExtractedData struct {
-	ChromeVersions   []string
-	FirefoxVersions  []string
-	SafariVersions   []string
-	EdgeVersions     []string
-	IOSVersions      []string
-	MacVersions      []string
-	AndroidVersions  []string
-	AndroidDevices   []AndroidDevice
-	WindowsVersions  []string
-	LinuxDesktops    []string
-}
-
-type AndroidDevice struct {
-	Model string
-	Build string
-}
+	// Every data.go update is also kept as a snapshot for ua.WithSnapshot
+	snapshotDir = "pkg/useragent/snapshots"
+)
 
 func main() {
-	fmt.Println("Downloading user-agents data...")
-	agents, err := downloadAndParse()
+	in := flag.String("in", "", "read the dataset from a local .json or .json.gz file (- for stdin) instead of downloading it")
+	jsonOut := flag.String("json", "", "write a ua.Dataset JSON file for ua.LoadDataset instead of regenerating data.go")
+	flag.Parse()
+
+	var agents []extract.Agent
+	var err error
+	if *in != "" {
+		fmt.Printf("Reading user-agents data from %s...\n", *in)
+		agents, err = load(*in)
+	} else {
+		fmt.Println("Downloading user-agents data...")
+		agents, err = download()
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error downloading data: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error reading data: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Printf("Downloaded %d user-agents\n", len(agents))
+	fmt.Printf("Loaded %d user-agents\n", len(agents))
 
 	fmt.Println("Extracting version components...")
-	data := extractVersions(agents)
+	data := extract.Extract(agents)
+	if len(data.BrowserOS) == 0 || len(data.DeviceAndroid) == 0 {
+		fmt.Fprintln(os.Stderr, "Error extracting data: no browser/OS or device/Android pairs")
+		os.Exit(1)
+	}
+
+	if *jsonOut != "" {
+		if err := writeDataset(*jsonOut, data, time.Now().UTC()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing dataset: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote %s\n", *jsonOut)
+		printStats(data)
+		printDrops(data.Dropped)
+		return
+	}
 
 	fmt.Println("Generating Go code...")
-	if err := generateCode(data); err != nil {
+	now := time.Now().UTC()
+	changed, err := generateCode(data, now)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating code: %v\n", err)
 		os.Exit(1)
 	}
 
 	fmt.Printf("Generated %s\n", outputFile)
+	if changed {
+		id := snapshotID(now)
+		if err := writeSnapshot(id, data, now); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing snapshot: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote snapshot %s\n", id)
+	}
 	printStats(data)
+	printDrops(data.Dropped)
 }
 
-func downloadAndParse() ([]UserAgent, error) {
+// download fetches the dataset published at dataURL
+func download() ([]extract.Agent, error) {
 	client := &http.Client{Timeout: 30 * time.Second}
 	resp, err := client.Get(dataURL)
 	if err != nil {
@@ -90,286 +112,90 @@ func downloadAndParse() ([]UserAgent, error) {
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("HTTP status %d", resp.StatusCode)
 	}
+	return extract.Decode(resp.Body)
+}
 
-	gz, err := gzip.NewReader(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("gzip reader failed: %w", err)
+// load reads a local dataset, "-" meaning stdin
+func load(path string) ([]extract.Agent, error) {
+	if path == "-" {
+		return extract.Decode(os.Stdin)
 	}
-	defer gz.Close()
-
-	data, err := io.ReadAll(gz)
+	f, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("read failed: %w", err)
+		return nil, err
 	}
-
-	var agents []UserAgent
-	if err := json.Unmarshal(data, &agents); err != nil {
-		return nil, fmt.Errorf("JSON unmarshal failed: %w", err)
-	}
-
-	return agents, nil
+	defer f.Close()
+	return extract.Decode(f)
 }
 
-var (
-	// Chrome/131.0.6778.85
-	chromeVersionRe = regexp.MustCompile(`Chrome/(\d+\.\d+\.\d+\.\d+)`)
-	// Firefox/133.0
-	firefoxVersionRe = regexp.MustCompile(`Firefox/(\d+\.\d+)`)
-	// Version/17.4 Safari
-	safariVersionRe = regexp.MustCompile(`Version/(\d+\.\d+(?:\.\d+)?) Safari`)
-	// Edg/131.0.2903.51
-	edgeVersionRe = regexp.MustCompile(`Edg/(\d+\.\d+\.\d+\.\d+)`)
-	// iPhone OS 17_4_1 or CPU OS 17_4
-	iosVersionRe = regexp.MustCompile(`(?:iPhone|CPU) OS (\d+_\d+(?:_\d+)?)`)
-	// Mac OS X 14_2_1 or Mac OS X 10_15_7
-	macVersionRe = regexp.MustCompile(`Mac OS X (\d+_\d+(?:_\d+)?)`)
-	// Android 14
-	androidVersionRe = regexp.MustCompile(`Android (\d+)`)
-	// Windows NT 10.0
-	windowsVersionRe = regexp.MustCompile(`Windows NT (\d+\.\d+)`)
-	// Linux; Android 14; SM-S911B Build/UP1A.231005.007
-	androidDeviceRe = regexp.MustCompile(`Android \d+; ([^)]+) Build/([A-Z0-9.]+)`)
-	// X11; Linux x86_64 or X11; Ubuntu; Linux x86_64
-	linuxDesktopRe = regexp.MustCompile(`\((X11; [^)]+)\)`)
-)
-
-func extractVersions(agents []UserAgent) ExtractedData {
-	// Maps to accumulate weights for each version
-	chromeWeights := make(map[string]float64)
-	firefoxWeights := make(map[string]float64)
-	safariWeights := make(map[string]float64)
-	edgeWeights := make(map[string]float64)
-	iosWeights := make(map[string]float64)
-	macWeights := make(map[string]float64)
-	androidWeights := make(map[string]float64)
-	windowsWeights := make(map[string]float64)
-	linuxWeights := make(map[string]float64)
-	deviceWeights := make(map[string]float64) // "model|build" -> weight
-
-	for _, ua := range agents {
-		s := ua.UserAgent
-		w := ua.Weight
-
-		// Chrome (but not Edge, Opera, Samsung)
-		if strings.Contains(s, "Chrome/") && !strings.Contains(s, "Edg/") &&
-			!strings.Contains(s, "OPR/") && !strings.Contains(s, "SamsungBrowser") {
-			if m := chromeVersionRe.FindStringSubmatch(s); m != nil {
-				chromeWeights[m[1]] += w
-			}
-		}
-
-		// Firefox
-		if m := firefoxVersionRe.FindStringSubmatch(s); m != nil {
-			firefoxWeights[m[1]] += w
-		}
-
-		// Safari (not Chrome-based)
-		if strings.Contains(s, "Safari/") && !strings.Contains(s, "Chrome/") {
-			if m := safariVersionRe.FindStringSubmatch(s); m != nil {
-				safariWeights[m[1]] += w
-			}
-		}
-
-		// Edge
-		if m := edgeVersionRe.FindStringSubmatch(s); m != nil {
-			edgeWeights[m[1]] += w
-		}
-
-		// iOS
-		if m := iosVersionRe.FindStringSubmatch(s); m != nil {
-			iosWeights[m[1]] += w
-		}
-
-		// macOS
-		if m := macVersionRe.FindStringSubmatch(s); m != nil {
-			macWeights[m[1]] += w
-		}
-
-		// Android version
-		if m := androidVersionRe.FindStringSubmatch(s); m != nil {
-			androidWeights[m[1]] += w
-		}
-
-		// Windows
-		if m := windowsVersionRe.FindStringSubmatch(s); m != nil {
-			windowsWeights[m[1]] += w
-		}
-
-		// Android device
-		if m := androidDeviceRe.FindStringSubmatch(s); m != nil {
-			model := strings.TrimSpace(m[1])
-			build := m[2]
-			// Skip generic "K" model
-			if model != "K" && len(model) > 2 {
-				key := model + "|" + build
-				deviceWeights[key] += w
-			}
-		}
-
-		// Linux desktop (X11; Linux x86_64, etc.)
-		if strings.Contains(s, "X11;") && strings.Contains(s, "Linux") && !strings.Contains(s, "Android") {
-			if m := linuxDesktopRe.FindStringSubmatch(s); m != nil {
-				platform := m[1]
-				// Remove Firefox rv: suffix
-				if idx := strings.Index(platform, "; rv:"); idx != -1 {
-					platform = platform[:idx]
-				}
-				linuxWeights[platform] += w
-			}
-		}
-	}
-
-	data := ExtractedData{
-		ChromeVersions:  topVersions(chromeWeights, topN),
-		FirefoxVersions: topVersions(firefoxWeights, topN),
-		SafariVersions:  topVersions(safariWeights, topN),
-		EdgeVersions:    topVersions(edgeWeights, topN),
-		IOSVersions:     topVersions(iosWeights, topN),
-		MacVersions:     topVersions(macWeights, 30),
-		AndroidVersions: topVersions(androidWeights, 10),
-		WindowsVersions: topVersions(windowsWeights, 10),
-		LinuxDesktops:   topVersions(linuxWeights, 20),
-		AndroidDevices:  topDevices(deviceWeights, 50),
+// writeDataset writes data generated at now as a ua.Dataset JSON file
+func writeDataset(path string, data extract.Data, now time.Time) error {
+	d := data.Dataset()
+	d.Source, d.Generated = sourceURL, now.Truncate(time.Second)
+	out, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
 	}
-
-	// Add fallback data if extracted data is sparse
-	data.LinuxDesktops = mergeUnique(data.LinuxDesktops, fallbackLinuxDesktops)
-	data.MacVersions = mergeUnique(data.MacVersions, fallbackMacVersions)
-	data.AndroidDevices = mergeDevices(data.AndroidDevices, fallbackAndroidDevices)
-
-	return data
-}
-
-// Fallback data for sparse categories
-var fallbackLinuxDesktops = []string{
-	"X11; Linux x86_64",
-	"X11; Linux i686",
-	"X11; Linux aarch64",
-	"X11; Ubuntu; Linux x86_64",
-	"X11; Fedora; Linux x86_64",
-	"X11; Debian; Linux x86_64",
-	"X11; Arch Linux; Linux x86_64",
-	"X11; CentOS; Linux x86_64",
-}
-
-var fallbackMacVersions = []string{
-	"10_15_7", "11_0", "11_6", "12_0", "12_6", "13_0", "13_6", "14_0", "14_4", "15_0",
+	return os.WriteFile(path, append(out, '\n'), 0644)
 }
 
-var fallbackAndroidDevices = []AndroidDevice{
-	{"Pixel 6", "TQ3A.230901.001"},
-	{"Pixel 7", "TQ3A.230901.001"},
-	{"Pixel 7 Pro", "TQ3A.230901.001"},
-	{"Pixel 8", "UQ1A.231205.015"},
-	{"Pixel 8 Pro", "UQ1A.231205.015"},
-	{"SM-S901B", "TP1A.220624.014"},  // Samsung S22
-	{"SM-S908B", "TP1A.220624.014"},  // Samsung S22 Ultra
-	{"SM-S911B", "TP1A.220624.014"},  // Samsung S23
-	{"SM-S918B", "TP1A.220624.014"},  // Samsung S23 Ultra
-	{"SM-S921B", "UP1A.231005.007"},  // Samsung S24
-	{"SM-S928B", "UP1A.231005.007"},  // Samsung S24 Ultra
-	{"SM-A536B", "TP1A.220624.014"},  // Samsung A53
-	{"SM-A546B", "UP1A.231005.007"},  // Samsung A54
-	{"SM-G998B", "TP1A.220624.014"},  // Samsung S21 Ultra
-	{"ONEPLUS A6013", "QKQ1.190716.003"},
-	{"IN2025", "RKQ1.211119.001"},         // OnePlus Nord
-	{"CPH2451", "TP1A.220905.001"},        // OPPO Find X5
-	{"M2101K6G", "TKQ1.221114.001"},       // Xiaomi 11T Pro
-	{"2201116SG", "TKQ1.221114.001"},      // Xiaomi 12
-	{"23049PCD8G", "UKQ1.231003.002"},     // Xiaomi 14
-	{"RMX3363", "TP1A.220905.001"},        // Realme GT 2 Pro
-	{"V2111", "TP1A.220624.014"},          // Vivo X70 Pro
-	{"LE2125", "RKQ1.211119.001"},         // OnePlus 9 Pro
-}
-
-func mergeUnique(a, b []string) []string {
-	seen := make(map[string]bool)
-	for _, v := range a {
-		seen[v] = true
-	}
-	result := append([]string{}, a...)
-	for _, v := range b {
-		if !seen[v] {
-			result = append(result, v)
-			seen[v] = true
+// writeSnapshot records data as the pinned snapshot id, with weights
+// rounded like data.go so both produce the same User-Agents
+func writeSnapshot(id string, data extract.Data, now time.Time) error {
+	d := data.Dataset()
+	d.ID, d.Source, d.Generated = id, sourceURL, now.Truncate(time.Second)
+	for _, table := range [][]ua.VersionWeight{d.Chrome, d.Firefox, d.Safari, d.Edge, d.Samsung,
+		d.Windows, d.MacOS, d.Linux, d.IOS, d.Android} {
+		for i := range table {
+			table[i].Weight = roundWeight(table[i].Weight)
 		}
 	}
-	return result
-}
-
-func mergeDevices(a, b []AndroidDevice) []AndroidDevice {
-	seen := make(map[string]bool)
-	for _, d := range a {
-		seen[d.Model] = true
-	}
-	result := append([]AndroidDevice{}, a...)
-	for _, d := range b {
-		if !seen[d.Model] {
-			result = append(result, d)
-			seen[d.Model] = true
-		}
+	for i := range d.AndroidDevices {
+		d.AndroidDevices[i].Weight = roundWeight(d.AndroidDevices[i].Weight)
 	}
-	return result
-}
-
-func topVersions(weights map[string]float64, n int) []string {
-	var vw []VersionWeight
-	for v, w := range weights {
-		vw = append(vw, VersionWeight{v, w})
+	for i := range d.BrowserOS {
+		d.BrowserOS[i].Weight = roundWeight(d.BrowserOS[i].Weight)
 	}
-
-	// Sort by weight descending
-	sort.Slice(vw, func(i, j int) bool {
-		return vw[i].Weight > vw[j].Weight
-	})
-
-	// Take top N
-	if len(vw) > n {
-		vw = vw[:n]
+	for i := range d.DeviceAndroid {
+		d.DeviceAndroid[i].Weight = roundWeight(d.DeviceAndroid[i].Weight)
 	}
 
-	// Sort by version string for stable output
-	sort.Slice(vw, func(i, j int) bool {
-		return vw[i].Version < vw[j].Version
-	})
-
-	result := make([]string, len(vw))
-	for i, v := range vw {
-		result[i] = v.Version
+	out, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
 	}
-	return result
-}
-
-func topDevices(weights map[string]float64, n int) []AndroidDevice {
-	var vw []VersionWeight
-	for v, w := range weights {
-		vw = append(vw, VersionWeight{v, w})
+	// Published snapshots are never overwritten
+	f, err := os.OpenFile(filepath.Join(snapshotDir, id+".json"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
 	}
-
-	sort.Slice(vw, func(i, j int) bool {
-		return vw[i].Weight > vw[j].Weight
-	})
-
-	if len(vw) > n {
-		vw = vw[:n]
+	if _, err := f.Write(append(out, '\n')); err != nil {
+		f.Close()
+		return err
 	}
+	return f.Close()
+}
 
-	// Sort by model name
-	sort.Slice(vw, func(i, j int) bool {
-		return vw[i].Version < vw[j].Version
-	})
-
-	result := make([]AndroidDevice, len(vw))
-	for i, v := range vw {
-		parts := strings.SplitN(v.Version, "|", 2)
-		if len(parts) == 2 {
-			result[i] = AndroidDevice{Model: parts[0], Build: parts[1]}
+// snapshotID returns the ID of a snapshot generated at now: its date, or
+// the date with the first free suffix (2026-01-18-2) if one exists already
+func snapshotID(now time.Time) string {
+	date := now.Format(time.DateOnly)
+	id := date
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(snapshotDir, id+".json")); os.IsNotExist(err) {
+			return id
 		}
+		id = fmt.Sprintf("%s-%d", date, n)
 	}
-	return result
 }
 
-func printStats(data ExtractedData) {
+// roundWeight rounds w to the precision formatWeight writes to data.go
+func roundWeight(w float64) float64 {
+	r, _ := strconv.ParseFloat(formatWeight(w), 64)
+	return r
+}
+
+func printStats(data extract.Data) {
 	fmt.Printf("\nExtracted:\n")
 	fmt.Printf("  Chrome versions:  %d\n", len(data.ChromeVersions))
 	fmt.Printf("  Firefox versions: %d\n", len(data.FirefoxVersions))
@@ -381,86 +207,118 @@ func printStats(data ExtractedData) {
 	fmt.Printf("  Windows versions: %d\n", len(data.WindowsVersions))
 	fmt.Printf("  Linux desktops:   %d\n", len(data.LinuxDesktops))
 	fmt.Printf("  Android devices:  %d\n", len(data.AndroidDevices))
+	fmt.Printf("  Browser/OS pairs: %d\n", len(data.BrowserOS))
+	fmt.Printf("  Device/Android:   %d\n", len(data.DeviceAndroid))
+}
+
+// printDrops reports every rejected entry and why
+func printDrops(dropped []extract.Drop) {
+	if len(dropped) == 0 {
+		return
+	}
+	fmt.Printf("\nDropped %d entries:\n", len(dropped))
+	for _, d := range dropped {
+		fmt.Printf("  %-8s %-16s %-9s %s\n", d.Family, d.Version, formatWeight(d.Weight), d.Reason)
+	}
 }
 
 const codeTemplate = `// Code generated by scripts/generate_data.go. DO NOT EDIT.
-// Source: https://github.com/intoli/user-agents
+// Source: {{.Source}}
 {{if .Timestamp}}// Generated: {{.Timestamp}}
 {{end}}
 package ua
 
+// Where and when this data was generated, see DataInfo
+const (
+	dataSource    = "{{.Source}}"
+	dataGenerated = "{{.Timestamp}}"
+)
+
 // Chrome versions extracted from real usage data (sorted by popularity)
-var chromeVersions = []string{
+var chromeVersions = newWeighted([]entry[string]{
 {{- range .Data.ChromeVersions}}
-	"{{.}}",
+	{"{{.Version}}", {{weight .Weight}}},
 {{- end}}
-}
+})
 
 // Firefox versions extracted from real usage data
-var firefoxVersions = []string{
+var firefoxVersions = newWeighted([]entry[string]{
 {{- range .Data.FirefoxVersions}}
-	"{{.}}",
+	{"{{.Version}}", {{weight .Weight}}},
 {{- end}}
-}
+})
 
 // Safari versions extracted from real usage data
-var safariVersions = []string{
+var safariVersions = newWeighted([]entry[string]{
 {{- range .Data.SafariVersions}}
-	"{{.}}",
+	{"{{.Version}}", {{weight .Weight}}},
 {{- end}}
-}
+})
 
 // Edge versions extracted from real usage data
-var edgeVersions = []string{
+var edgeVersions = newWeighted([]entry[string]{
 {{- range .Data.EdgeVersions}}
-	"{{.}}",
+	{"{{.Version}}", {{weight .Weight}}},
 {{- end}}
-}
+})
 
 // Windows NT versions (sorted by popularity)
-var windowsVersions = []string{
+var windowsVersions = newWeighted([]entry[string]{
 {{- range .Data.WindowsVersions}}
-	"{{.}}",
+	{"{{.Version}}", {{weight .Weight}}},
 {{- end}}
-}
+})
 
 // macOS versions (underscore format: 14_2_1)
-var macVersions = []string{
+var macVersions = newWeighted([]entry[string]{
 {{- range .Data.MacVersions}}
-	"{{.}}",
+	{"{{.Version}}", {{weight .Weight}}},
 {{- end}}
-}
+})
 
 // Linux desktop platforms extracted from real usage data
-var linuxDesktops = []string{
+var linuxDesktops = newWeighted([]entry[string]{
 {{- range .Data.LinuxDesktops}}
-	"{{.}}",
+	{"{{.Version}}", {{weight .Weight}}},
 {{- end}}
-}
+})
 
 // iOS versions (underscore format: 17_4_1)
-var iosVersions = []string{
+var iosVersions = newWeighted([]entry[string]{
 {{- range .Data.IOSVersions}}
-	"{{.}}",
+	{"{{.Version}}", {{weight .Weight}}},
 {{- end}}
-}
+})
 
 // Android versions
-var androidVersions = []string{
+var androidVersions = newWeighted([]entry[string]{
 {{- range .Data.AndroidVersions}}
-	"{{.}}",
+	{"{{.Version}}", {{weight .Weight}}},
 {{- end}}
-}
+})
 
 // Android devices extracted from real usage data (model, build ID)
-var androidDevices = []struct {
-	model string
-	build string
-}{
+var androidDevices = newWeighted([]entry[androidDevice]{
 {{- range .Data.AndroidDevices}}
-	{"{{.Model}}", "{{.Build}}"},
+	{androidDevice{"{{.Model}}", "{{.Build}}"}, {{weight .Weight}}},
 {{- end}}
-}
+})
+
+// OS releases seen with each browser version, see BrowserOSWeight
+var browserOSVersions = []BrowserOSWeight{
+{{- range .Data.BrowserOS}}
+	{"{{.Browser}}", "{{.Version}}", "{{.OS}}", "{{.OSVersion}}", {{weight .Weight}}},
+{{- end}}
+{{- if .Data.BrowserOS}}
+{{end}}}
+
+// Android versions seen with each device model, see DeviceAndroidWeight
+var deviceAndroidVersions = []DeviceAndroidWeight{
+{{- range .Data.DeviceAndroid}}
+	{"{{.Model}}", "{{.Android}}", {{weight .Weight}}},
+{{- end}}
+{{- if .Data.DeviceAndroid}}
+{{end}}}
 
 // WebKit version (used in Safari)
 const webkitVersion = "605.1.15"
@@ -469,23 +327,29 @@ const webkitVersion = "605.1.15"
 const appleWebKitChrome = "537.36"
 `
 
-func generateCode(data ExtractedData) error {
-	tmpl, err := template.New("code").Parse(codeTemplate)
+// generateCode writes data.go stamped with now, reporting whether the
+// data changed
+func generateCode(data extract.Data, now time.Time) (bool, error) {
+	tmpl, err := template.New("code").Funcs(template.FuncMap{
+		"weight": formatWeight,
+	}).Parse(codeTemplate)
 	if err != nil {
-		return fmt.Errorf("template parse failed: %w", err)
+		return false, fmt.Errorf("template parse failed: %w", err)
 	}
 
 	// Generate without timestamp first (for comparison)
 	var buf strings.Builder
 	templateData := struct {
+		Source    string
 		Timestamp string
-		Data      ExtractedData
+		Data      extract.Data
 	}{
+		Source:    sourceURL,
 		Timestamp: "",
 		Data:      data,
 	}
 	if err := tmpl.Execute(&buf, templateData); err != nil {
-		return fmt.Errorf("template execute failed: %w", err)
+		return false, fmt.Errorf("template execute failed: %w", err)
 	}
 	newContent := buf.String()
 
@@ -498,29 +362,35 @@ func generateCode(data ExtractedData) error {
 	// Compare (strip timestamp from new content too)
 	if stripTimestamp(newContent) == existingContent {
 		fmt.Println("No changes detected, skipping update")
-		return nil
+		return false, nil
 	}
 
 	// Changes detected - regenerate with timestamp
 	buf.Reset()
-	templateData.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	templateData.Timestamp = now.Format(time.RFC3339)
 	if err := tmpl.Execute(&buf, templateData); err != nil {
-		return fmt.Errorf("template execute failed: %w", err)
+		return false, fmt.Errorf("template execute failed: %w", err)
 	}
 
 	if err := os.WriteFile(outputFile, []byte(buf.String()), 0644); err != nil {
-		return fmt.Errorf("write file failed: %w", err)
+		return false, fmt.Errorf("write file failed: %w", err)
 	}
 
-	return nil
+	return true, nil
+}
+
+// formatWeight renders a weight as a Go float literal
+func formatWeight(w float64) string {
+	return strconv.FormatFloat(w, 'g', 6, 64)
 }
 
-// stripTimestamp removes the "// Generated: ..." line for comparison
+// stripTimestamp removes the "// Generated: ..." and dataGenerated lines
+// for comparison
 func stripTimestamp(content string) string {
 	lines := strings.Split(content, "\n")
 	var result []string
 	for _, line := range lines {
-		if !strings.HasPrefix(line, "// Generated:") {
+		if !strings.HasPrefix(line, "// Generated:") && !strings.HasPrefix(line, "\tdataGenerated ") {
 			result = append(result, line)
 		}
 	}